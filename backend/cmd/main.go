@@ -7,6 +7,7 @@ import (
 	"github.com/swaggo/swag"
 
 	"github.com/steve-phan/page-insight-tool/internal/config"
+	"github.com/steve-phan/page-insight-tool/internal/openapi"
 	"github.com/steve-phan/page-insight-tool/internal/server"
 )
 
@@ -51,7 +52,8 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 
-	swag.Register(api.SwaggerInfo.InstanceName(), api.SwaggerInfo)
+	// Extractors declare their extension sections at runtime, so they are added to the generated docs here
+	swag.Register(api.SwaggerInfo.InstanceName(), openapi.NewSpec(api.SwaggerInfo, srv.SectionSchemas()))
 
 	// Wait for shutdown signal
 	srv.WaitForShutdown()
@@ -24,7 +24,7 @@ const docTemplate = `{
     "paths": {
         "/analyze": {
             "get": {
-                "description": "Analyzes a web page and extracts HTML version, title, headings, links, login forms, and CSR detection information",
+                "description": "Analyzes a web page and extracts HTML version, title, headings, links, login forms, and CSR detection information\nPass extractors to run only some of them (e.g. \"title,headings\" skips the slow external link checks); sections of the other extractors are left out of the response.\nAn extractor that fails, panics or exceeds its time budget doesn't fail the request: the response is still 200 with \"partial\" set, and its diagnostics entry says what went wrong.",
                 "consumes": [
                     "application/json"
                 ],
@@ -43,6 +43,85 @@ const docTemplate = `{
                         "name": "url",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "title,headings",
+                        "description": "Comma-separated extractors to run: title, headings, links, login_form, accessibility, version, csr, meta, structured_data (default: all)",
+                        "name": "extractors",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "summary",
+                            "detailed"
+                        ],
+                        "type": "string",
+                        "description": "summary (default) returns link counts; detailed adds links.details with every link",
+                        "name": "links",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Check every link again instead of using cached link check results",
+                        "name": "links_recheck",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "internal",
+                            "external",
+                            "invalid"
+                        ],
+                        "type": "string",
+                        "description": "Only list links of this type (detailed mode)",
+                        "name": "links_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "same_host",
+                            "same_site",
+                            "external"
+                        ],
+                        "type": "string",
+                        "description": "Only list links with this scope (detailed mode)",
+                        "name": "links_scope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ok",
+                            "broken",
+                            "unchecked",
+                            "disallowed",
+                            "not_checked"
+                        ],
+                        "type": "string",
+                        "description": "Only list links with this status (detailed mode)",
+                        "name": "links_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list links with this rel value, e.g. nofollow (detailed mode)",
+                        "name": "links_rel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page of the link list, from 1 (detailed mode)",
+                        "name": "links_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Links per page, up to 500 (detailed mode)",
+                        "name": "links_page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -53,7 +132,235 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid URL",
+                        "description": "Invalid URL, unknown extractor or invalid link report parameter",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Disallowed by robots.txt or an internal destination (DESTINATION_BLOCKED)",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "HTML parsing error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "502": {
+                        "description": "Target unreachable, non-OK response or too many redirects (TOO_MANY_REDIRECTS)",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyze/batch": {
+            "post": {
+                "description": "Enqueues an analysis of a list of web pages, run with bounded concurrency, and returns the job immediately. Accepts a JSON array of URLs, a JSON object with a \"urls\" field, or a CSV upload (text/csv body or multipart \"file\" field, first column is the URL).\nPoll the job until its status is \"done\"; batch_result then holds a result or error per URL, so one bad URL never fails the whole batch.",
+                "consumes": [
+                    "application/json",
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Analyze many web pages",
+                "parameters": [
+                    {
+                        "description": "URLs to analyze",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchAnalysisRequest"
+                        }
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file with one URL per row",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or URL list",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyze/html": {
+            "post": {
+                "description": "Runs the configured extractors over an HTML document sent in the request body instead of fetching a URL, for pages the server can't reach.\nSend JSON with \"html\" and an optional \"base_url\", or a raw text/html body with an optional base_url query parameter. The base URL only resolves relative links; nothing is fetched from it.",
+                "consumes": [
+                    "application/json",
+                    "text/html"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Analyze a submitted HTML document",
+                "parameters": [
+                    {
+                        "description": "HTML document and optional base URL",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AnalyzeHTMLRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "example": "https://intranet.example.com/",
+                        "description": "Base URL for a raw text/html body",
+                        "name": "base_url",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "title,headings",
+                        "description": "Comma-separated extractors to run: title, headings, links, login_form, accessibility, version, csr, meta, structured_data (default: all)",
+                        "name": "extractors",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "summary",
+                            "detailed"
+                        ],
+                        "type": "string",
+                        "description": "summary (default) returns link counts; detailed adds links.details with every link",
+                        "name": "links",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Check every link again instead of using cached link check results",
+                        "name": "links_recheck",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "internal",
+                            "external",
+                            "invalid"
+                        ],
+                        "type": "string",
+                        "description": "Only list links of this type (detailed mode)",
+                        "name": "links_type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "same_host",
+                            "same_site",
+                            "external"
+                        ],
+                        "type": "string",
+                        "description": "Only list links with this scope (detailed mode)",
+                        "name": "links_scope",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ok",
+                            "broken",
+                            "unchecked",
+                            "disallowed",
+                            "not_checked"
+                        ],
+                        "type": "string",
+                        "description": "Only list links with this status (detailed mode)",
+                        "name": "links_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list links with this rel value, e.g. nofollow (detailed mode)",
+                        "name": "links_rel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page of the link list, from 1 (detailed mode)",
+                        "name": "links_page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Links per page, up to 500 (detailed mode)",
+                        "name": "links_page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AnalysisResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, base URL, unknown extractor or invalid link report parameter",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "413": {
+                        "description": "HTML document too large",
                         "schema": {
                             "$ref": "#/definitions/models.HTTPError"
                         }
@@ -79,6 +386,64 @@ const docTemplate = `{
                 }
             }
         },
+        "/crawl": {
+            "post": {
+                "description": "Enqueues a crawl that starts at the seed URL and follows internal links breadth-first, analyzing every page up to max_depth and max_pages (both capped by the server configuration), and returns the job immediately.\nInclude and exclude are URL path patterns where \"*\" matches any characters; excludes win over includes. Poll the job until its status is \"done\"; crawl_result then lists per-page results plus site-level aggregates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Crawl and analyze a site",
+                "parameters": [
+                    {
+                        "description": "Seed URL and crawl limits",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CrawlRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL, limits or patterns",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/health": {
             "get": {
                 "description": "Returns the health status of the API including version, environment, and server status",
@@ -101,58 +466,596 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "health.HealthResponse": {
+        },
+        "/jobs": {
+            "post": {
+                "description": "Enqueues an analysis of the given URL and returns immediately. Poll the job until its status is \"done\" or \"failed\".",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Create an analysis job",
+                "parameters": [
+                    {
+                        "description": "URL to analyze",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateJobRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created job"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}": {
+            "get": {
+                "description": "Returns the job status (queued, running, done, failed). Finished jobs include the analysis result (batch_result for batch jobs, crawl_result for crawl jobs) or the error and expire after the configured TTL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Get an analysis job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a job. Queued jobs are dropped and running jobs are cancelled.",
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Delete an analysis job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Job deleted"
+                    },
+                    "404": {
+                        "description": "Job not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/robots": {
+            "get": {
+                "description": "Fetches (or reads from cache) the robots.txt of the URL's host and reports whether our user agent may fetch the URL, which rule decided it and any Crawl-delay.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analysis"
+                ],
+                "summary": "Test a URL against robots.txt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "https://example.com/private/page",
+                        "description": "URL to test",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RobotsCheckResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid URL",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "health.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "build_date": {
+                    "type": "string",
+                    "example": "2025-10-29T22:00:00Z"
+                },
+                "environment": {
+                    "type": "string",
+                    "example": "development"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "timestamp": {
+                    "type": "string",
+                    "example": "2025-10-29T22:00:00Z"
+                },
+                "version": {
+                    "type": "string",
+                    "example": "1.0.0"
+                }
+            }
+        },
+        "models.AnalysisResponse": {
+            "type": "object",
+            "properties": {
+                "analysis_time_ms": {
+                    "type": "integer",
+                    "example": 150
+                },
+                "charset": {
+                    "description": "Charset is the encoding the fetched page was decoded from; absent for submitted HTML",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Charset"
+                        }
+                    ]
+                },
+                "diagnostics": {
+                    "description": "Diagnostics lists every extractor that ran, so a failed extractor can be told apart from an empty section",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExtractorDiagnostic"
+                    }
+                },
+                "has_login_form": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "headings": {
+                    "$ref": "#/definitions/models.Headings"
+                },
+                "html_version": {
+                    "type": "string",
+                    "example": "HTML5"
+                },
+                "links": {
+                    "$ref": "#/definitions/models.Links"
+                },
+                "page_title": {
+                    "type": "string",
+                    "example": "Google"
+                },
+                "partial": {
+                    "description": "Partial is set when an extractor failed, panicked or timed out; the other sections are still complete",
+                    "type": "boolean",
+                    "example": false
+                },
+                "redirects": {
+                    "description": "Redirects is the redirect chain of the fetched page, empty if it was served directly",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Redirects"
+                        }
+                    ]
+                },
+                "response": {
+                    "description": "Response describes the HTTP exchange of the fetched page; absent for submitted HTML",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.HTTPResponse"
+                        }
+                    ]
+                },
+                "sections": {
+                    "description": "Sections holds the sections of extension extractors, keyed by section name",
+                    "type": "object"
+                },
+                "timing": {
+                    "description": "Timing breaks AnalysisTime down into fetch, parse and extractor phases",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Timing"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.AnalyzeHTMLRequest": {
+            "type": "object",
+            "required": [
+                "html"
+            ],
+            "properties": {
+                "base_url": {
+                    "description": "BaseURL resolves relative links; without it they are counted as internal",
+                    "type": "string",
+                    "example": "https://intranet.example.com/"
+                },
+                "html": {
+                    "type": "string",
+                    "example": "\u003c!DOCTYPE html\u003e\u003chtml\u003e\u003chead\u003e\u003ctitle\u003eIntranet\u003c/title\u003e\u003c/head\u003e\u003cbody\u003e\u003ca href=\"/docs\"\u003eDocs\u003c/a\u003e\u003c/body\u003e\u003c/html\u003e"
+                }
+            }
+        },
+        "models.BatchAnalysisRequest": {
+            "type": "object",
+            "properties": {
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com",
+                        "https://www.google.com"
+                    ]
+                }
+            }
+        },
+        "models.BatchAnalysisResponse": {
+            "type": "object",
+            "properties": {
+                "analysis_time_ms": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchAnalysisResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.BatchAnalysisResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/models.HTTPError"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.AnalysisResponse"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com"
+                }
+            }
+        },
+        "models.Charset": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "shift_jis"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "bom",
+                        "header",
+                        "meta",
+                        "default"
+                    ],
+                    "example": "meta"
+                }
+            }
+        },
+        "models.CrawlPage": {
+            "type": "object",
+            "properties": {
+                "depth": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "error": {
+                    "$ref": "#/definitions/models.HTTPError"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.AnalysisResponse"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/about"
+                }
+            }
+        },
+        "models.CrawlReport": {
+            "type": "object",
+            "properties": {
+                "analysis_time_ms": {
+                    "type": "integer",
+                    "example": 9500
+                },
+                "pages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CrawlPage"
+                    }
+                },
+                "seed_url": {
+                    "type": "string",
+                    "example": "https://example.com"
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.CrawlSummary"
+                }
+            }
+        },
+        "models.CrawlRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "exclude": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/blog/tag/*"
+                    ]
+                },
+                "include": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/blog/*"
+                    ]
+                },
+                "max_depth": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "max_pages": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com"
+                }
+            }
+        },
+        "models.CrawlSummary": {
+            "type": "object",
+            "properties": {
+                "avg_analysis_time_ms": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "headings": {
+                    "$ref": "#/definitions/models.Headings"
+                },
+                "html_versions": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "links": {
+                    "$ref": "#/definitions/models.Links"
+                },
+                "max_depth_reached": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pages_analyzed": {
+                    "type": "integer",
+                    "example": 48
+                },
+                "pages_discovered": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "pages_failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pages_with_login_form": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pages_without_title": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.CreateJobRequest": {
             "type": "object",
+            "required": [
+                "url"
+            ],
             "properties": {
-                "build_date": {
+                "url": {
                     "type": "string",
-                    "example": "2025-10-29T22:00:00Z"
+                    "example": "https://www.google.com"
+                }
+            }
+        },
+        "models.ExtractorDiagnostic": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 812.4
                 },
-                "environment": {
-                    "type": "string",
-                    "example": "development"
+                "implicit": {
+                    "description": "Implicit is set for an extractor that only ran because a requested one depends on it; its section is left out",
+                    "type": "boolean",
+                    "example": false
                 },
-                "status": {
-                    "type": "string",
-                    "example": "healthy"
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "inaccessible link https://example.com/old: HTTP 404"
+                    ]
                 },
-                "timestamp": {
+                "name": {
                     "type": "string",
-                    "example": "2025-10-29T22:00:00Z"
+                    "example": "links"
                 },
-                "version": {
+                "status": {
                     "type": "string",
-                    "example": "1.0.0"
+                    "enum": [
+                        "ok",
+                        "warning",
+                        "error",
+                        "timeout"
+                    ],
+                    "example": "warning"
                 }
             }
         },
-        "models.AnalysisResponse": {
+        "models.FetchTiming": {
             "type": "object",
             "properties": {
-                "analysis_time_ms": {
-                    "type": "integer",
-                    "example": 150
+                "dns_lookup_ms": {
+                    "type": "number",
+                    "example": 12.5
                 },
-                "has_login_form": {
-                    "type": "boolean",
-                    "example": true
+                "download_ms": {
+                    "description": "ContentDownload is the time from the first byte of the final response until its body was read",
+                    "type": "number",
+                    "example": 35.2
                 },
-                "headings": {
-                    "$ref": "#/definitions/models.Headings"
+                "tcp_connect_ms": {
+                    "type": "number",
+                    "example": 20.1
                 },
-                "html_version": {
-                    "type": "string",
-                    "example": "HTML5"
+                "tls_handshake_ms": {
+                    "type": "number",
+                    "example": 45.3
                 },
-                "links": {
-                    "$ref": "#/definitions/models.Links"
+                "total_ms": {
+                    "description": "Total is the whole fetch, including redirects and robots.txt checks",
+                    "type": "number",
+                    "example": 301.4
                 },
-                "page_title": {
-                    "type": "string",
-                    "example": "Google"
+                "ttfb_ms": {
+                    "description": "TimeToFirstByte is the wait between sending each request and the first byte of its response",
+                    "type": "number",
+                    "example": 180.7
                 }
             }
         },
@@ -183,6 +1086,100 @@ const docTemplate = `{
                 }
             }
         },
+        "models.HTTPResponse": {
+            "type": "object",
+            "properties": {
+                "body_size": {
+                    "type": "integer",
+                    "example": 18342
+                },
+                "content_encoding": {
+                    "type": "string",
+                    "example": "gzip"
+                },
+                "content_length": {
+                    "description": "ContentLength is the declared Content-Length; absent when the server didn't send one",
+                    "type": "integer",
+                    "example": 5120
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "text/html; charset=utf-8"
+                },
+                "final_url": {
+                    "type": "string",
+                    "example": "https://www.example.com/"
+                },
+                "headers": {
+                    "description": "Headers holds a fixed set of caching, security and server headers, keyed by canonical name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "HTTP/2.0"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "transfer_size": {
+                    "description": "TransferSize is the size of the body on the wire, BodySize its size after decompression",
+                    "type": "integer",
+                    "example": 5120
+                }
+            }
+        },
+        "models.HeadingEntry": {
+            "type": "object",
+            "properties": {
+                "hidden": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "level": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "path": {
+                    "description": "Path locates the heading in the DOM, e.g. html \u003e body \u003e main#content \u003e h2:nth-of-type(2)",
+                    "type": "string",
+                    "example": "html \u003e body \u003e main#content \u003e h2:nth-of-type(2)"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Pricing"
+                }
+            }
+        },
+        "models.HeadingIssue": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "h2 is followed by h4"
+                },
+                "path": {
+                    "description": "Path is the heading the issue is about; absent for page-level issues like a missing h1",
+                    "type": "string",
+                    "example": "html \u003e body \u003e main#content \u003e h4"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "missing_h1",
+                        "multiple_h1",
+                        "skipped_level",
+                        "empty_heading",
+                        "long_heading",
+                        "hidden_heading"
+                    ],
+                    "example": "skipped_level"
+                }
+            }
+        },
         "models.Headings": {
             "type": "object",
             "properties": {
@@ -209,23 +1206,413 @@ const docTemplate = `{
                 "h6": {
                     "type": "integer",
                     "example": 60
+                },
+                "issues": {
+                    "description": "Issues flags problems with the heading structure; hidden headings are left out of the structure checks",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HeadingIssue"
+                    }
+                },
+                "outline": {
+                    "description": "Outline lists every heading in document order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HeadingEntry"
+                    }
+                }
+            }
+        },
+        "models.Job": {
+            "type": "object",
+            "properties": {
+                "batch_result": {
+                    "$ref": "#/definitions/models.BatchAnalysisResponse"
+                },
+                "crawl": {
+                    "$ref": "#/definitions/models.CrawlRequest"
+                },
+                "crawl_result": {
+                    "$ref": "#/definitions/models.CrawlReport"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "error": {
+                    "$ref": "#/definitions/models.HTTPError"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3f6c2a9e-8d1b-4c7a-9f0e-2b5d7c1a4e8f"
+                },
+                "result": {
+                    "$ref": "#/definitions/models.AnalysisResponse"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.JobStatus"
+                        }
+                    ],
+                    "example": "queued"
+                },
+                "type": {
+                    "enum": [
+                        "analyze",
+                        "batch",
+                        "crawl"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.JobType"
+                        }
+                    ],
+                    "example": "analyze"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.google.com"
+                },
+                "urls": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://example.com",
+                        "https://www.google.com"
+                    ]
+                }
+            }
+        },
+        "models.JobStatus": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "done",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "JobStatusQueued",
+                "JobStatusRunning",
+                "JobStatusDone",
+                "JobStatusFailed"
+            ]
+        },
+        "models.JobType": {
+            "type": "string",
+            "enum": [
+                "analyze",
+                "batch",
+                "crawl"
+            ],
+            "x-enum-varnames": [
+                "JobTypeAnalyze",
+                "JobTypeBatch",
+                "JobTypeCrawl"
+            ]
+        },
+        "models.LinkDetail": {
+            "type": "object",
+            "properties": {
+                "cached": {
+                    "description": "Cached is set when the status came from the shared link check cache instead of a new check",
+                    "type": "boolean",
+                    "example": true
+                },
+                "error": {
+                    "description": "Error is the error type of a broken link, e.g. HTTP_NOT_FOUND or NETWORK_DNS",
+                    "type": "string",
+                    "example": "HTTP_NOT_FOUND"
+                },
+                "final_url": {
+                    "description": "FinalURL is where the check was redirected to; absent when the link wasn't redirected",
+                    "type": "string",
+                    "example": "https://example.com/about-us"
+                },
+                "rel": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nofollow",
+                        "noopener"
+                    ]
+                },
+                "response_time_ms": {
+                    "type": "number",
+                    "example": 132.5
+                },
+                "scope": {
+                    "description": "Scope refines the type; absent for invalid links",
+                    "type": "string",
+                    "enum": [
+                        "same_host",
+                        "same_site",
+                        "external"
+                    ],
+                    "example": "external"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "broken",
+                        "unchecked",
+                        "disallowed",
+                        "not_checked"
+                    ],
+                    "example": "broken"
+                },
+                "status_code": {
+                    "description": "StatusCode is the HTTP status the check ended with, 0 if it got no response",
+                    "type": "integer",
+                    "example": 404
+                },
+                "target": {
+                    "type": "string",
+                    "example": "_blank"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "About us"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "internal",
+                        "external",
+                        "invalid"
+                    ],
+                    "example": "external"
+                },
+                "url": {
+                    "description": "URL is the resolved link target, or the raw href if it couldn't be resolved",
+                    "type": "string",
+                    "example": "https://example.com/about"
+                }
+            }
+        },
+        "models.LinkDetails": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LinkDetail"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "total": {
+                    "description": "Total is the number of links matching the filters, across all pages",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.LinkStats": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "disallowed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ok": {
+                    "type": "integer",
+                    "example": 18
+                },
+                "redirected": {
+                    "description": "Redirected counts checked links that ended up at a different URL",
+                    "type": "integer",
+                    "example": 3
+                },
+                "unchecked": {
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
         "models.Links": {
             "type": "object",
             "properties": {
+                "details": {
+                    "description": "Details lists every link; only present when the detailed link report is requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LinkDetails"
+                        }
+                    ]
+                },
                 "external": {
                     "type": "integer",
                     "example": 20
                 },
+                "external_stats": {
+                    "description": "ExternalStats breaks the external link checks down by outcome",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LinkStats"
+                        }
+                    ]
+                },
                 "inaccessible": {
                     "type": "integer",
                     "example": 30
                 },
                 "internal": {
+                    "description": "Internal counts links to the page's own site: its host, other subdomains of its registrable domain and first-party hosts",
                     "type": "integer",
                     "example": 10
+                },
+                "internal_stats": {
+                    "description": "InternalStats does the same for internal links; only set when internal links are checked",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.LinkStats"
+                        }
+                    ]
+                },
+                "same_site": {
+                    "description": "SameSite counts the internal links that leave the page's host",
+                    "type": "integer",
+                    "example": 4
+                },
+                "unchecked": {
+                    "description": "Unchecked counts external links whose check didn't finish before the link check deadline",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "models.RedirectHop": {
+            "type": "object",
+            "properties": {
+                "location": {
+                    "type": "string",
+                    "example": "https://www.example.com/"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 301
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://example.com/"
+                }
+            }
+        },
+        "models.Redirects": {
+            "type": "object",
+            "properties": {
+                "hops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RedirectHop"
+                    }
+                },
+                "https_downgrade": {
+                    "description": "HTTPSDowngrade is set when a hop leads from https to http",
+                    "type": "boolean",
+                    "example": false
+                },
+                "loop": {
+                    "description": "Loop is set when the chain revisits a URL, e.g. a cookie check bouncing back to the original page",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mixed_www": {
+                    "description": "MixedWWW is set when a hop switches between the www and the apex host of a domain",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.RobotsCheckResponse": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "crawl_delay_seconds": {
+                    "type": "number",
+                    "example": 2
+                },
+                "group": {
+                    "type": "string",
+                    "example": "*"
+                },
+                "robots_status": {
+                    "type": "string",
+                    "enum": [
+                        "fetched",
+                        "missing",
+                        "unreachable",
+                        "error"
+                    ],
+                    "example": "fetched"
+                },
+                "robots_url": {
+                    "type": "string",
+                    "example": "https://example.com/robots.txt"
+                },
+                "rule": {
+                    "type": "string",
+                    "example": "Disallow: /private"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/private/page"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "PageInsightTool"
+                }
+            }
+        },
+        "models.Timing": {
+            "type": "object",
+            "properties": {
+                "extraction_ms": {
+                    "description": "Extraction is the wall time of all extractors, which run concurrently",
+                    "type": "number",
+                    "example": 812.9
+                },
+                "extractors_ms": {
+                    "description": "Extractors holds the run time of each extractor by name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "fetch": {
+                    "description": "Fetch is absent for submitted HTML",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FetchTiming"
+                        }
+                    ]
+                },
+                "parse_ms": {
+                    "type": "number",
+                    "example": 4.2
                 }
             }
         }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"strings"
 	"time"
 
@@ -16,6 +17,12 @@ type Config struct {
 	Redis     RedisConfig     `mapstructure:"redis"`
 	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
 	Cache     CacheConfig     `mapstructure:"cache"`
+	Batch     BatchConfig     `mapstructure:"batch"`
+	Jobs      JobsConfig      `mapstructure:"jobs"`
+	Crawl     CrawlConfig     `mapstructure:"crawl"`
+	Robots    RobotsConfig    `mapstructure:"robots"`
+	Security  SecurityConfig  `mapstructure:"security"`
+	LinkCheck LinkCheckConfig `mapstructure:"link_check"`
 }
 
 // ServerConfig holds server-related configuration
@@ -53,6 +60,13 @@ type AnalysisConfig struct {
 	Timeout     time.Duration `mapstructure:"timeout"`
 	VerifySSL   bool          `mapstructure:"verify_ssl"`
 	MaxBodySize int64         `mapstructure:"max_body_size"`
+	// ExtractorTimeout is the time budget of each extractor; a slower extractor is reported and skipped
+	ExtractorTimeout time.Duration `mapstructure:"extractor_timeout"`
+	// MaxRedirects is how many redirects a fetch follows before it fails
+	MaxRedirects int `mapstructure:"max_redirects"`
+	// FirstPartyHosts lists domains whose links count as same-site on every page, e.g. CDNs and sister brands
+	// An entry also covers its subdomains
+	FirstPartyHosts []string `mapstructure:"first_party_hosts"`
 }
 
 // RedisConfig holds Redis-related configuration
@@ -81,6 +95,59 @@ type CacheConfig struct {
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
 }
 
+// BatchConfig holds batch analysis configuration
+type BatchConfig struct {
+	MaxURLs     int `mapstructure:"max_urls"`
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+// JobsConfig holds asynchronous analysis job configuration
+type JobsConfig struct {
+	Workers   int           `mapstructure:"workers"`
+	ResultTTL time.Duration `mapstructure:"result_ttl"`
+	QueueTTL  time.Duration `mapstructure:"queue_ttl"`
+}
+
+// CrawlConfig holds site crawl configuration
+// MaxDepth and MaxPages are both the defaults and the upper bounds for a crawl request
+type CrawlConfig struct {
+	MaxDepth    int `mapstructure:"max_depth"`
+	MaxPages    int `mapstructure:"max_pages"`
+	Concurrency int `mapstructure:"concurrency"`
+}
+
+// RobotsConfig holds robots.txt compliance configuration
+type RobotsConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	UserAgent     string        `mapstructure:"user_agent"`
+	CacheTTL      time.Duration `mapstructure:"cache_ttl"`
+	MaxCrawlDelay time.Duration `mapstructure:"max_crawl_delay"`
+}
+
+// SecurityConfig holds outbound request protection
+type SecurityConfig struct {
+	// BlockPrivateNetworks refuses connections to loopback, link-local, private, CGNAT and cloud metadata addresses
+	BlockPrivateNetworks bool `mapstructure:"block_private_networks"`
+	// AllowedNetworks lists IPs or CIDRs exempt from the block, e.g. internal sites or an outbound proxy
+	AllowedNetworks []string `mapstructure:"allowed_networks"`
+}
+
+// LinkCheckConfig holds link reachability checking configuration
+type LinkCheckConfig struct {
+	Workers            int           `mapstructure:"workers"`
+	PerHostConcurrency int           `mapstructure:"per_host_concurrency"`
+	Timeout            time.Duration `mapstructure:"timeout"`
+	// Deadline bounds all checks of one page; links not checked by then are reported as unchecked
+	Deadline time.Duration `mapstructure:"deadline"`
+	// CheckInternal checks internal links too; otherwise only external links are checked
+	CheckInternal bool `mapstructure:"check_internal"`
+	// CacheEnabled shares link check results across analyses through Redis
+	CacheEnabled bool `mapstructure:"cache_enabled"`
+	// CacheTTL is how long a reachable link is trusted; CacheFailureTTL is kept short so fixed links recover quickly
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`
+	CacheFailureTTL time.Duration `mapstructure:"cache_failure_ttl"`
+}
+
 // LoadConfig loads configuration from file and environment variables
 func LoadConfig(configPath string) (*Config, error) {
 	viper.SetConfigFile(configPath)
@@ -143,6 +210,9 @@ func setDefaults() {
 	viper.SetDefault("analysis.timeout", 10)
 	viper.SetDefault("analysis.verify_ssl", false)
 	viper.SetDefault("analysis.max_body_size", int64(10))
+	viper.SetDefault("analysis.extractor_timeout", "10s")
+	viper.SetDefault("analysis.max_redirects", 5)
+	viper.SetDefault("analysis.first_party_hosts", []string{})
 
 	// Redis defaults
 	viper.SetDefault("redis.host", "localhost")
@@ -163,6 +233,40 @@ func setDefaults() {
 	viper.SetDefault("cache.default_ttl", "300s")
 	viper.SetDefault("cache.max_size", 1000)
 	viper.SetDefault("cache.cleanup_interval", "30s")
+
+	// Batch defaults
+	viper.SetDefault("batch.max_urls", 500)
+	viper.SetDefault("batch.concurrency", 8)
+
+	// Jobs defaults
+	viper.SetDefault("jobs.workers", 4)
+	viper.SetDefault("jobs.result_ttl", "1h")
+	viper.SetDefault("jobs.queue_ttl", "24h")
+
+	// Crawl defaults
+	viper.SetDefault("crawl.max_depth", 3)
+	viper.SetDefault("crawl.max_pages", 100)
+	viper.SetDefault("crawl.concurrency", 4)
+
+	// Robots defaults
+	viper.SetDefault("robots.enabled", true)
+	viper.SetDefault("robots.user_agent", "PageInsightTool")
+	viper.SetDefault("robots.cache_ttl", "24h")
+	viper.SetDefault("robots.max_crawl_delay", "10s")
+
+	// Security defaults
+	viper.SetDefault("security.block_private_networks", true)
+	viper.SetDefault("security.allowed_networks", []string{})
+
+	// Link check defaults
+	viper.SetDefault("link_check.workers", 16)
+	viper.SetDefault("link_check.per_host_concurrency", 2)
+	viper.SetDefault("link_check.timeout", "5s")
+	viper.SetDefault("link_check.deadline", "8s")
+	viper.SetDefault("link_check.check_internal", false)
+	viper.SetDefault("link_check.cache_enabled", true)
+	viper.SetDefault("link_check.cache_ttl", "24h")
+	viper.SetDefault("link_check.cache_failure_ttl", "15m")
 }
 
 // validateConfig validates the configuration
@@ -175,6 +279,17 @@ func validateConfig(config *Config) error {
 	if config.Analysis.Timeout <= 0 || config.Analysis.Timeout >= 1000 {
 		return fmt.Errorf("invalid analysis timeout: %v", config.Analysis.Timeout)
 	}
+	if config.Analysis.ExtractorTimeout < 0 {
+		return fmt.Errorf("invalid analysis extractor timeout: %v", config.Analysis.ExtractorTimeout)
+	}
+	if config.Analysis.MaxRedirects < 0 {
+		return fmt.Errorf("invalid analysis max redirects: %d", config.Analysis.MaxRedirects)
+	}
+	for _, host := range config.Analysis.FirstPartyHosts {
+		if strings.TrimSpace(host) == "" || strings.ContainsAny(host, "/: ") {
+			return fmt.Errorf("invalid analysis first-party host: %q", host)
+		}
+	}
 	// Validate Redis config
 	if config.Redis.Port <= 0 || config.Redis.Port > 65535 {
 		return fmt.Errorf("invalid Redis port: %d", config.Redis.Port)
@@ -197,6 +312,71 @@ func validateConfig(config *Config) error {
 			return fmt.Errorf("invalid rate limit window: %v", config.RateLimit.Window)
 		}
 	}
+	// Validate batch config
+	if config.Batch.MaxURLs < 0 {
+		return fmt.Errorf("invalid batch max URLs: %d", config.Batch.MaxURLs)
+	}
+	if config.Batch.Concurrency < 0 {
+		return fmt.Errorf("invalid batch concurrency: %d", config.Batch.Concurrency)
+	}
+	// Validate jobs config
+	if config.Jobs.Workers < 0 {
+		return fmt.Errorf("invalid jobs workers: %d", config.Jobs.Workers)
+	}
+	if config.Jobs.ResultTTL < 0 {
+		return fmt.Errorf("invalid jobs result TTL: %v", config.Jobs.ResultTTL)
+	}
+	if config.Jobs.QueueTTL < 0 {
+		return fmt.Errorf("invalid jobs queue TTL: %v", config.Jobs.QueueTTL)
+	}
+	// Validate crawl config
+	if config.Crawl.MaxDepth < 0 {
+		return fmt.Errorf("invalid crawl max depth: %d", config.Crawl.MaxDepth)
+	}
+	if config.Crawl.MaxPages < 0 {
+		return fmt.Errorf("invalid crawl max pages: %d", config.Crawl.MaxPages)
+	}
+	if config.Crawl.Concurrency < 0 {
+		return fmt.Errorf("invalid crawl concurrency: %d", config.Crawl.Concurrency)
+	}
+	// Validate robots config
+	if config.Robots.CacheTTL < 0 {
+		return fmt.Errorf("invalid robots cache TTL: %v", config.Robots.CacheTTL)
+	}
+	if config.Robots.MaxCrawlDelay < 0 {
+		return fmt.Errorf("invalid robots max crawl delay: %v", config.Robots.MaxCrawlDelay)
+	}
+	// Validate link check config
+	if config.LinkCheck.Workers < 0 {
+		return fmt.Errorf("invalid link check workers: %d", config.LinkCheck.Workers)
+	}
+	if config.LinkCheck.PerHostConcurrency < 0 {
+		return fmt.Errorf("invalid link check per-host concurrency: %d", config.LinkCheck.PerHostConcurrency)
+	}
+	if config.LinkCheck.Timeout < 0 {
+		return fmt.Errorf("invalid link check timeout: %v", config.LinkCheck.Timeout)
+	}
+	if config.LinkCheck.Deadline < 0 {
+		return fmt.Errorf("invalid link check deadline: %v", config.LinkCheck.Deadline)
+	}
+	// The checks run inside the links extractor; a longer deadline would time out the whole section
+	if config.LinkCheck.Deadline > 0 && config.Analysis.ExtractorTimeout > 0 && config.LinkCheck.Deadline >= config.Analysis.ExtractorTimeout {
+		return fmt.Errorf("link check deadline %v must be shorter than the analysis extractor timeout %v", config.LinkCheck.Deadline, config.Analysis.ExtractorTimeout)
+	}
+	if config.LinkCheck.CacheTTL < 0 {
+		return fmt.Errorf("invalid link check cache TTL: %v", config.LinkCheck.CacheTTL)
+	}
+	if config.LinkCheck.CacheFailureTTL < 0 {
+		return fmt.Errorf("invalid link check cache failure TTL: %v", config.LinkCheck.CacheFailureTTL)
+	}
+	// Validate security config
+	for _, network := range config.Security.AllowedNetworks {
+		if _, err := netip.ParsePrefix(network); err != nil {
+			if _, err := netip.ParseAddr(network); err != nil {
+				return fmt.Errorf("invalid security allowed network: %q", network)
+			}
+		}
+	}
 	return nil
 }
 
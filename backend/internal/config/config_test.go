@@ -133,6 +133,22 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "link check deadline outlasting the extractor timeout",
+			config: &Config{
+				Server: ServerConfig{
+					Port: 8080,
+				},
+				Analysis: AnalysisConfig{
+					Timeout:          30,
+					ExtractorTimeout: 10 * time.Second,
+				},
+				LinkCheck: LinkCheckConfig{
+					Deadline: 20 * time.Second,
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -24,14 +24,22 @@ const (
 	ErrorTypeNetworkSSL        ErrorType = "NETWORK_SSL"
 
 	// HTTP-specific errors
-	ErrorTypeHTTPForbidden   ErrorType = "HTTP_FORBIDDEN"
-	ErrorTypeHTTPNotFound    ErrorType = "HTTP_NOT_FOUND"
-	ErrorTypeHTTPNonOKStatus ErrorType = "HTTP_NON_OK_STATUS"
+	ErrorTypeHTTPForbidden    ErrorType = "HTTP_FORBIDDEN"
+	ErrorTypeHTTPNotFound     ErrorType = "HTTP_NOT_FOUND"
+	ErrorTypeHTTPNonOKStatus  ErrorType = "HTTP_NON_OK_STATUS"
+	ErrorTypeTooManyRedirects ErrorType = "TOO_MANY_REDIRECTS"
+
+	// Crawling policy errors
+	ErrorTypeRobotsDisallowed   ErrorType = "ROBOTS_DISALLOWED"
+	ErrorTypeDestinationBlocked ErrorType = "DESTINATION_BLOCKED"
 
 	// Content processing errors
 	ErrorTypeHTMLParse     ErrorType = "HTML_PARSE"
 	ErrorTypeContentTooBig ErrorType = "CONTENT_TOO_BIG"
 
+	// Resource lookup errors
+	ErrorTypeNotFound ErrorType = "NOT_FOUND"
+
 	// Internal/system errors
 	ErrorTypeInternal ErrorType = "INTERNAL"
 )
@@ -159,6 +167,53 @@ func NewHTTPNonOKStatusError(url string, statusCode int) *DomainError {
 	}
 }
 
+// NewTooManyRedirectsError reports a fetch that gave up after maxRedirects redirects
+// The chain lists the URLs visited, ending with the redirect that was not followed
+func NewTooManyRedirectsError(url string, maxRedirects int, chain []string, loop bool) *DomainError {
+	message := fmt.Sprintf("stopped after %d redirects for URL: %s", maxRedirects, url)
+	if loop {
+		message = fmt.Sprintf("redirect loop for URL: %s", url)
+	}
+	return &DomainError{
+		Type:       ErrorTypeTooManyRedirects,
+		Message:    message,
+		StatusCode: http.StatusBadGateway,
+		Details: map[string]interface{}{
+			"url":           url,
+			"max_redirects": maxRedirects,
+			"chain":         chain,
+			"loop":          loop,
+		},
+	}
+}
+
+// Crawling Policy Errors
+func NewRobotsDisallowedError(url string, userAgent string, rule string) *DomainError {
+	return &DomainError{
+		Type:       ErrorTypeRobotsDisallowed,
+		Message:    fmt.Sprintf("fetching URL is disallowed by robots.txt: %s", url),
+		StatusCode: http.StatusForbidden,
+		Details: map[string]interface{}{
+			"url":        url,
+			"user_agent": userAgent,
+			"rule":       rule,
+		},
+	}
+}
+
+// NewDestinationBlockedError reports a connection refused because the address is on an internal network
+func NewDestinationBlockedError(address string, reason string) *DomainError {
+	return &DomainError{
+		Type:       ErrorTypeDestinationBlocked,
+		Message:    fmt.Sprintf("destination address is not allowed: %s", address),
+		StatusCode: http.StatusForbidden,
+		Details: map[string]interface{}{
+			"address": address,
+			"reason":  reason,
+		},
+	}
+}
+
 // Content Processing Errors
 func NewHTMLParseError(url string, cause error) *DomainError {
 	return &DomainError{
@@ -183,6 +238,19 @@ func NewContentTooBigError(url string, size int64, maxSize int64) *DomainError {
 	}
 }
 
+// Resource Errors
+func NewNotFoundError(resource string, id string) *DomainError {
+	return &DomainError{
+		Type:       ErrorTypeNotFound,
+		Message:    fmt.Sprintf("%s not found: %s", resource, id),
+		StatusCode: http.StatusNotFound,
+		Details: map[string]interface{}{
+			"resource": resource,
+			"id":       id,
+		},
+	}
+}
+
 // Internal Errors
 func NewInternalError(message string, cause error) *DomainError {
 	return &DomainError{
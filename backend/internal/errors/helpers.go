@@ -36,13 +36,29 @@ func IsHTTPError(err error) bool {
 	var domainErr *DomainError
 	if errors.As(err, &domainErr) {
 		switch domainErr.Type {
-		case ErrorTypeHTTPForbidden, ErrorTypeHTTPNotFound, ErrorTypeHTTPNonOKStatus:
+		case ErrorTypeHTTPForbidden, ErrorTypeHTTPNotFound, ErrorTypeHTTPNonOKStatus, ErrorTypeTooManyRedirects:
 			return true
 		}
 	}
 	return false
 }
 
+func IsRobotsDisallowedError(err error) bool {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Type == ErrorTypeRobotsDisallowed
+	}
+	return false
+}
+
+func IsDestinationBlockedError(err error) bool {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Type == ErrorTypeDestinationBlocked
+	}
+	return false
+}
+
 func IsContentProcessingError(err error) bool {
 	var domainErr *DomainError
 	if errors.As(err, &domainErr) {
@@ -65,6 +81,12 @@ func IsInternalError(err error) bool {
 // ClassifyNetworkError converts network errors to domain-specific errors
 // This function provides centralized error classification for network-related failures
 func ClassifyNetworkError(targetURL string, err error) error {
+	// Keep domain errors raised inside the client (e.g. by the redirect policy)
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+
 	// Check for timeout errors
 	if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
 		return NewNetworkTimeoutError(targetURL, err)
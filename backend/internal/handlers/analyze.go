@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/steve-phan/page-insight-tool/internal/memcach"
 	"github.com/steve-phan/page-insight-tool/internal/middleware"
@@ -17,25 +19,46 @@ import (
 // AnalyzeHandler handles URL analysis requests with clean error handling
 // @Summary      Analyze a web page
 // @Description  Analyzes a web page and extracts HTML version, title, headings, links, login forms, and CSR detection information
+// @Description  Pass extractors to run only some of them (e.g. "title,headings" skips the slow external link checks); sections of the other extractors are left out of the response.
+// @Description  An extractor that fails, panics or exceeds its time budget doesn't fail the request: the response is still 200 with "partial" set, and its diagnostics entry says what went wrong.
 // @Tags         Analysis
 // @Accept       json
 // @Produce      json
-// @Param        url   query     string  true  "URL of the web page to analyze"  example(https://example.com)
-// @Success      200   {object}  models.AnalysisResponse
-// @Failure      400   {object}  models.HTTPError  "Invalid URL"
-// @Failure      422   {object}  models.HTTPError  "HTML parsing error"
-// @Failure      429   {object}  models.HTTPError  "Rate limit exceeded"
-// @Failure      500   {object}  models.HTTPError  "Internal server error"
+// @Param        url              query     string  true   "URL of the web page to analyze"  example(https://example.com)
+// @Param        extractors       query     string  false  "Comma-separated extractors to run: title, headings, links, login_form, accessibility, version, csr, meta, structured_data (default: all)"  example(title,headings)
+// @Param        links            query     string  false  "summary (default) returns link counts; detailed adds links.details with every link"  Enums(summary, detailed)
+// @Param        links_recheck    query     bool    false  "Check every link again instead of using cached link check results"  default(false)
+// @Param        links_type       query     string  false  "Only list links of this type (detailed mode)"  Enums(internal, external, invalid)
+// @Param        links_scope      query     string  false  "Only list links with this scope (detailed mode)"  Enums(same_host, same_site, external)
+// @Param        links_status     query     string  false  "Only list links with this status (detailed mode)"  Enums(ok, broken, unchecked, disallowed, not_checked)
+// @Param        links_rel        query     string  false  "Only list links with this rel value, e.g. nofollow (detailed mode)"
+// @Param        links_page       query     int     false  "Page of the link list, from 1 (detailed mode)"  default(1)
+// @Param        links_page_size  query     int     false  "Links per page, up to 500 (detailed mode)"  default(50)
+// @Success      200              {object}  models.AnalysisResponse
+// @Failure      400              {object}  models.HTTPError  "Invalid URL, unknown extractor or invalid link report parameter"
+// @Failure      403              {object}  models.HTTPError  "Disallowed by robots.txt or an internal destination (DESTINATION_BLOCKED)"
+// @Failure      422              {object}  models.HTTPError  "HTML parsing error"
+// @Failure      429              {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500              {object}  models.HTTPError  "Internal server error"
+// @Failure      502              {object}  models.HTTPError  "Target unreachable, non-OK response or too many redirects (TOO_MANY_REDIRECTS)"
 // @Router       /analyze [get]
 func AnalyzeHandler(analyzerService *analyzer.AnalyzerService, errorHandler *middleware.ErrorHandler, urlValidator *validation.URLValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract and validate URL parameter
 		rawURL := c.Query("url")
+		extractors := parseExtractorNames(c)
+		linkQuery, err := parseLinkQuery(c)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+		// The full link list is cached, so paging through it doesn't analyze the page again
+		cacheKey := analysisCacheKey(rawURL, extractors, linkQuery.detailed)
 
-		// Check memcache for existing analysis result
+		// Check memcache for existing analysis result; a recheck bypasses it and refreshes it
 
-		cachedData, found := memcach.GetMemCache().Get(rawURL)
-		if found {
+		cachedData, found := memcach.GetMemCache().Get(cacheKey)
+		if found && !linkQuery.recheck {
 
 			// Log cache hit
 			fmt.Println("Cache hit for URL:", rawURL)
@@ -46,6 +69,7 @@ func AnalyzeHandler(analyzerService *analyzer.AnalyzerService, errorHandler *mid
 				// If unmarshaling fails, treat as cache miss
 				fmt.Println("Cache unmarshal error for URL:", rawURL, "error:", err)
 			}
+			linkQuery.apply(&response)
 			c.JSON(http.StatusOK, response)
 			return
 		}
@@ -56,18 +80,57 @@ func AnalyzeHandler(analyzerService *analyzer.AnalyzerService, errorHandler *mid
 		}
 
 		// Perform analysis using the pre-configured analyzer service
-		response, err := analyzerService.Analyze(c.Request.Context(), rawURL)
+		response, err := analyzerService.Analyze(c.Request.Context(), rawURL, analysisOptions(extractors, linkQuery)...)
 		if err != nil {
 			errorHandler.HandleError(c, err)
 			return
 		}
 
-		// Store result in memcache
-		if data, err := json.Marshal(response); err == nil {
-			memcach.GetMemCache().Set(rawURL, data)
+		// Store result in memcache; partial results are not cached since the failure may be transient
+		if data, err := json.Marshal(response); err == nil && !response.Partial {
+			memcach.GetMemCache().Set(cacheKey, data)
 		}
 
 		// Success response
+		linkQuery.apply(&response)
 		c.JSON(http.StatusOK, response)
 	}
 }
+
+// parseExtractorNames reads the comma-separated extractors query parameter
+func parseExtractorNames(c *gin.Context) []string {
+	var names []string
+	for _, name := range strings.Split(c.Query("extractors"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// analysisOptions builds the request options of an analysis
+func analysisOptions(extractors []string, linkQuery linkQuery) []analyzer.RequestOption {
+	options := []analyzer.RequestOption{analyzer.WithSelectedExtractors(extractors...)}
+	if linkQuery.detailed {
+		options = append(options, analyzer.WithLinkDetails())
+	}
+	if linkQuery.recheck {
+		options = append(options, analyzer.WithLinkRecheck())
+	}
+	return options
+}
+
+// analysisCacheKey keys cached results by URL, extractor selection and link report mode, so a partial
+// result is never served for a request that wants other sections
+func analysisCacheKey(rawURL string, extractors []string, linkDetails bool) string {
+	key := rawURL
+	if len(extractors) > 0 {
+		sorted := append([]string(nil), extractors...)
+		sort.Strings(sorted)
+		key += "#extractors=" + strings.Join(sorted, ",")
+	}
+	if linkDetails {
+		key += "#links=detailed"
+	}
+	return key
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisCacheKey(t *testing.T) {
+	c := newHTMLTestContext(t, "/api/v1/analyze?url=https://example.com&extractors=headings,+title,,", "", "")
+	extractors := parseExtractorNames(c)
+	assert.Equal(t, []string{"headings", "title"}, extractors)
+
+	assert.Equal(t, "https://example.com", analysisCacheKey("https://example.com", nil, false))
+	assert.Equal(t, "https://example.com#extractors=headings,title", analysisCacheKey("https://example.com", extractors, false))
+	assert.Equal(t, analysisCacheKey("https://example.com", []string{"title", "headings"}, false), analysisCacheKey("https://example.com", extractors, false))
+	assert.Equal(t, "https://example.com#extractors=headings,title#links=detailed", analysisCacheKey("https://example.com", extractors, true))
+}
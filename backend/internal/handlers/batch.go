@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/middleware"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxBatchBodySize caps the size of a batch request body (JSON or CSV)
+const maxBatchBodySize = 1 << 20 // 1MB
+
+// BatchAnalyzeHandler enqueues a batch of URLs as an asynchronous job
+// @Summary      Analyze many web pages
+// @Description  Enqueues an analysis of a list of web pages, run with bounded concurrency, and returns the job immediately. Accepts a JSON array of URLs, a JSON object with a "urls" field, or a CSV upload (text/csv body or multipart "file" field, first column is the URL).
+// @Description  Poll the job until its status is "done"; batch_result then holds a result or error per URL, so one bad URL never fails the whole batch.
+// @Tags         Analysis
+// @Accept       json
+// @Accept       text/csv
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        request  body      models.BatchAnalysisRequest  false  "URLs to analyze"
+// @Param        file     formData  file                         false  "CSV file with one URL per row"
+// @Success      202      {object}  models.Job
+// @Header       202      {string}  Location  "URL of the created job"
+// @Failure      400      {object}  models.HTTPError  "Invalid request body or URL list"
+// @Failure      429      {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500      {object}  models.HTTPError  "Internal server error"
+// @Router       /analyze/batch [post]
+func BatchAnalyzeHandler(jobService *jobs.JobService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		urls, err := parseBatchURLs(c)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		job, err := jobService.CreateBatch(c.Request.Context(), urls)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.Header("Location", jobsPath+"/"+job.ID)
+		c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// parseBatchURLs reads the URL list from a JSON body or a CSV upload
+func parseBatchURLs(c *gin.Context) ([]string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBatchBodySize)
+
+	switch c.ContentType() {
+	case "multipart/form-data":
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError("file", nil, "CSV file is required")
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError("file", fileHeader.Filename, "unable to read uploaded file")
+		}
+		defer file.Close()
+		return parseCSVURLs(file)
+	case "text/csv":
+		return parseCSVURLs(c.Request.Body)
+	default:
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError("body", nil, "unable to read request body")
+		}
+		return parseJSONURLs(body)
+	}
+}
+
+// parseJSONURLs accepts either a bare JSON array or a {"urls": [...]} object
+func parseJSONURLs(body []byte) ([]string, error) {
+	body = bytes.TrimSpace(body)
+
+	var urls []string
+	if bytes.HasPrefix(body, []byte("[")) {
+		if err := json.Unmarshal(body, &urls); err != nil {
+			return nil, domainerrors.NewInvalidInputError("body", nil, "expected a JSON array of URL strings")
+		}
+	} else {
+		var request models.BatchAnalysisRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return nil, domainerrors.NewInvalidInputError("body", nil, "expected a JSON object with a \"urls\" array")
+		}
+		urls = request.URLs
+	}
+
+	return compactURLs(urls), nil
+}
+
+// parseCSVURLs reads the first column of every CSV row, skipping an optional "url" header
+func parseCSVURLs(r io.Reader) ([]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, domainerrors.NewInvalidInputError("file", nil, "malformed CSV")
+	}
+
+	urls := make([]string, 0, len(records))
+	for i, record := range records {
+		if len(record) == 0 {
+			continue
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "url") {
+			continue
+		}
+		urls = append(urls, record[0])
+	}
+
+	return compactURLs(urls), nil
+}
+
+// compactURLs trims whitespace and drops blank entries
+func compactURLs(urls []string) []string {
+	compacted := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if u = strings.TrimSpace(u); u != "" {
+			compacted = append(compacted, u)
+		}
+	}
+	return compacted
+}
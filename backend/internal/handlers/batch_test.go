@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestContext(t *testing.T, contentType string, body []byte) *gin.Context {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := http.NewRequest(http.MethodPost, "/api/v1/analyze/batch", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	c.Request = req
+	return c
+}
+
+func TestParseBatchURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:        "JSON array",
+			contentType: "application/json",
+			body:        `["https://a.com", " https://b.com ", ""]`,
+			expected:    []string{"https://a.com", "https://b.com"},
+		},
+		{
+			name:        "JSON object",
+			contentType: "application/json",
+			body:        `{"urls": ["https://a.com"]}`,
+			expected:    []string{"https://a.com"},
+		},
+		{
+			name:        "CSV body with header",
+			contentType: "text/csv",
+			body:        "url,note\nhttps://a.com,first\n\nhttps://b.com\n",
+			expected:    []string{"https://a.com", "https://b.com"},
+		},
+		{
+			name:        "malformed JSON",
+			contentType: "application/json",
+			body:        `{"urls": [`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newBatchTestContext(t, tt.contentType, []byte(tt.body))
+
+			urls, err := parseBatchURLs(c)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, urls)
+		})
+	}
+}
+
+func TestParseBatchURLs_MultipartCSV(t *testing.T) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "urls.csv")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("https://a.com\nhttps://b.com\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	c := newBatchTestContext(t, writer.FormDataContentType(), body.Bytes())
+
+	urls, err := parseBatchURLs(c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.com", "https://b.com"}, urls)
+
+	// Missing file field
+	c = newBatchTestContext(t, writer.FormDataContentType(), []byte(strings.Repeat("-", 10)))
+	_, err = parseBatchURLs(c)
+	assert.Error(t, err)
+}
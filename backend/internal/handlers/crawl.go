@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/middleware"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CrawlHandler enqueues a crawl of a site from a seed URL as an asynchronous job
+// @Summary      Crawl and analyze a site
+// @Description  Enqueues a crawl that starts at the seed URL and follows internal links breadth-first, analyzing every page up to max_depth and max_pages (both capped by the server configuration), and returns the job immediately.
+// @Description  Include and exclude are URL path patterns where "*" matches any characters; excludes win over includes. Poll the job until its status is "done"; crawl_result then lists per-page results plus site-level aggregates.
+// @Tags         Analysis
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CrawlRequest  true  "Seed URL and crawl limits"
+// @Success      202      {object}  models.Job
+// @Header       202      {string}  Location  "URL of the created job"
+// @Failure      400      {object}  models.HTTPError  "Invalid URL, limits or patterns"
+// @Failure      429      {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500      {object}  models.HTTPError  "Internal server error"
+// @Router       /crawl [post]
+func CrawlHandler(jobService *jobs.JobService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request models.CrawlRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			errorHandler.HandleError(c, domainerrors.NewInvalidInputError("body", nil, "expected a JSON object with a \"url\" field"))
+			return
+		}
+
+		job, err := jobService.CreateCrawl(c.Request.Context(), request)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.Header("Location", jobsPath+"/"+job.ID)
+		c.JSON(http.StatusAccepted, job)
+	}
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/steve-phan/page-insight-tool/internal/services"
 	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
 	"github.com/steve-phan/page-insight-tool/internal/services/health"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
 	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 	"github.com/steve-phan/page-insight-tool/internal/validation"
 
 	"github.com/gin-gonic/gin"
@@ -18,9 +20,11 @@ type HandlerFactory struct {
 	config       *config.Config
 	analyzer     *analyzer.AnalyzerService
 	health       *health.HealthService
+	jobs         *jobs.JobService
 	errorHandler *middleware.ErrorHandler
 	urlValidator *validation.URLValidator
 	redis        *redis.RedisService
+	robots       *robots.RobotsService
 }
 
 // NewHandlerFactory creates a new handler factory with dependencies
@@ -29,9 +33,11 @@ func NewHandlerFactory(services *services.Services) *HandlerFactory {
 		config:       services.Config,
 		analyzer:     services.Analyzer,
 		health:       services.Health,
+		jobs:         services.Jobs,
 		errorHandler: middleware.NewErrorHandler(),
 		urlValidator: validation.NewURLValidator(),
 		redis:        services.Redis,
+		robots:       services.Robots,
 	}
 }
 
@@ -50,6 +56,41 @@ func (hf *HandlerFactory) AnalyzeHandler() gin.HandlerFunc {
 	return AnalyzeHandler(hf.analyzer, hf.errorHandler, hf.urlValidator)
 }
 
+// AnalyzeHTMLHandler returns the submitted HTML analysis handler
+func (hf *HandlerFactory) AnalyzeHTMLHandler() gin.HandlerFunc {
+	return AnalyzeHTMLHandler(hf.analyzer, hf.errorHandler)
+}
+
+// BatchAnalyzeHandler returns the handler enqueuing batch analysis jobs
+func (hf *HandlerFactory) BatchAnalyzeHandler() gin.HandlerFunc {
+	return BatchAnalyzeHandler(hf.jobs, hf.errorHandler)
+}
+
+// CrawlHandler returns the handler enqueuing site crawl jobs
+func (hf *HandlerFactory) CrawlHandler() gin.HandlerFunc {
+	return CrawlHandler(hf.jobs, hf.errorHandler)
+}
+
+// RobotsCheckHandler returns the robots.txt test handler
+func (hf *HandlerFactory) RobotsCheckHandler() gin.HandlerFunc {
+	return RobotsCheckHandler(hf.robots, hf.errorHandler, hf.urlValidator)
+}
+
+// CreateJobHandler returns the handler enqueuing asynchronous analysis jobs
+func (hf *HandlerFactory) CreateJobHandler() gin.HandlerFunc {
+	return CreateJobHandler(hf.jobs, hf.errorHandler)
+}
+
+// GetJobHandler returns the handler reporting job status and results
+func (hf *HandlerFactory) GetJobHandler() gin.HandlerFunc {
+	return GetJobHandler(hf.jobs, hf.errorHandler)
+}
+
+// DeleteJobHandler returns the handler deleting or cancelling jobs
+func (hf *HandlerFactory) DeleteJobHandler() gin.HandlerFunc {
+	return DeleteJobHandler(hf.jobs, hf.errorHandler)
+}
+
 // RedisService returns the Redis service
 func (hf *HandlerFactory) RedisService() *redis.RedisService {
 	return hf.redis
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/middleware"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyzeHTMLHandler handles analysis of HTML documents submitted in the request body
+// @Summary      Analyze a submitted HTML document
+// @Description  Runs the configured extractors over an HTML document sent in the request body instead of fetching a URL, for pages the server can't reach.
+// @Description  Send JSON with "html" and an optional "base_url", or a raw text/html body with an optional base_url query parameter. The base URL only resolves relative links; nothing is fetched from it.
+// @Tags         Analysis
+// @Accept       json
+// @Accept       html
+// @Produce      json
+// @Param        request          body      models.AnalyzeHTMLRequest  true   "HTML document and optional base URL"
+// @Param        base_url         query     string                     false  "Base URL for a raw text/html body"  example(https://intranet.example.com/)
+// @Param        extractors       query     string                     false  "Comma-separated extractors to run: title, headings, links, login_form, accessibility, version, csr, meta, structured_data (default: all)"  example(title,headings)
+// @Param        links            query     string                     false  "summary (default) returns link counts; detailed adds links.details with every link"  Enums(summary, detailed)
+// @Param        links_recheck    query     bool                       false  "Check every link again instead of using cached link check results"  default(false)
+// @Param        links_type       query     string                     false  "Only list links of this type (detailed mode)"  Enums(internal, external, invalid)
+// @Param        links_scope      query     string                     false  "Only list links with this scope (detailed mode)"  Enums(same_host, same_site, external)
+// @Param        links_status     query     string                     false  "Only list links with this status (detailed mode)"  Enums(ok, broken, unchecked, disallowed, not_checked)
+// @Param        links_rel        query     string                     false  "Only list links with this rel value, e.g. nofollow (detailed mode)"
+// @Param        links_page       query     int                        false  "Page of the link list, from 1 (detailed mode)"  default(1)
+// @Param        links_page_size  query     int                        false  "Links per page, up to 500 (detailed mode)"  default(50)
+// @Success      200              {object}  models.AnalysisResponse
+// @Failure      400              {object}  models.HTTPError  "Invalid request body, base URL, unknown extractor or invalid link report parameter"
+// @Failure      413              {object}  models.HTTPError  "HTML document too large"
+// @Failure      422              {object}  models.HTTPError  "HTML parsing error"
+// @Failure      429              {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500              {object}  models.HTTPError  "Internal server error"
+// @Router       /analyze/html [post]
+func AnalyzeHTMLHandler(analyzerService *analyzer.AnalyzerService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		linkQuery, err := parseLinkQuery(c)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		request, err := parseHTMLRequest(c, analyzerService.MaxBodySize())
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		response, err := analyzerService.AnalyzeHTML(c.Request.Context(), request.HTML, request.BaseURL,
+			analysisOptions(parseExtractorNames(c), linkQuery)...)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		linkQuery.apply(&response)
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// parseHTMLRequest reads the document from a JSON body or a raw text/html body
+func parseHTMLRequest(c *gin.Context, maxSize int64) (models.AnalyzeHTMLRequest, error) {
+	// JSON escaping can double the size of a document, so allow headroom; the analyzer enforces the real limit
+	limit := 2 * maxSize
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return models.AnalyzeHTMLRequest{}, domainerrors.NewContentTooBigError(c.Query("base_url"), maxBytesErr.Limit+1, maxSize)
+		}
+		return models.AnalyzeHTMLRequest{}, domainerrors.NewInvalidInputError("body", nil, "unable to read request body")
+	}
+
+	if c.ContentType() == "text/html" {
+		return models.AnalyzeHTMLRequest{HTML: string(body), BaseURL: c.Query("base_url")}, nil
+	}
+
+	var request models.AnalyzeHTMLRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return models.AnalyzeHTMLRequest{}, domainerrors.NewInvalidInputError("body", nil, "expected JSON with an \"html\" field or a text/html body")
+	}
+	return request, nil
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHTMLTestContext(t *testing.T, target, contentType, body string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	c.Request = req
+	return c
+}
+
+func TestParseHTMLRequest(t *testing.T) {
+	c := newHTMLTestContext(t, "/api/v1/analyze/html", "application/json",
+		`{"html": "<title>Doc</title>", "base_url": "https://intranet.example.com/"}`)
+	request, err := parseHTMLRequest(c, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "<title>Doc</title>", request.HTML)
+	assert.Equal(t, "https://intranet.example.com/", request.BaseURL)
+
+	c = newHTMLTestContext(t, "/api/v1/analyze/html?base_url=https://intranet.example.com/", "text/html; charset=utf-8",
+		"<title>Doc</title>")
+	request, err = parseHTMLRequest(c, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "<title>Doc</title>", request.HTML)
+	assert.Equal(t, "https://intranet.example.com/", request.BaseURL)
+
+	c = newHTMLTestContext(t, "/api/v1/analyze/html", "application/json", `<title>Doc</title>`)
+	_, err = parseHTMLRequest(c, 1024)
+	assert.Error(t, err, "a raw document needs the text/html content type")
+
+	c = newHTMLTestContext(t, "/api/v1/analyze/html", "text/html", strings.Repeat("a", 100))
+	_, err = parseHTMLRequest(c, 10)
+	var domainErr *domainerrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainerrors.ErrorTypeContentTooBig, domainErr.Type)
+}
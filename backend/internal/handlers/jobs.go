@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/middleware"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jobsPath is where jobs are polled, as routed in routes.setupAPIRoutes
+const jobsPath = "/api/v1/jobs"
+
+// CreateJobHandler enqueues an asynchronous analysis job
+// @Summary      Create an analysis job
+// @Description  Enqueues an analysis of the given URL and returns immediately. Poll the job until its status is "done" or "failed".
+// @Tags         Analysis
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateJobRequest  true  "URL to analyze"
+// @Success      202      {object}  models.Job
+// @Header       202      {string}  Location  "URL of the created job"
+// @Failure      400      {object}  models.HTTPError  "Invalid URL"
+// @Failure      429      {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500      {object}  models.HTTPError  "Internal server error"
+// @Router       /jobs [post]
+func CreateJobHandler(jobService *jobs.JobService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request models.CreateJobRequest
+		if err := c.ShouldBindJSON(&request); err != nil {
+			errorHandler.HandleError(c, domainerrors.NewInvalidInputError("body", nil, "expected a JSON object with a \"url\" field"))
+			return
+		}
+
+		job, err := jobService.Create(c.Request.Context(), request.URL)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.Header("Location", jobsPath+"/"+job.ID)
+		c.JSON(http.StatusAccepted, job)
+	}
+}
+
+// GetJobHandler reports the status of a job and its result once finished
+// @Summary      Get an analysis job
+// @Description  Returns the job status (queued, running, done, failed). Finished jobs include the analysis result (batch_result for batch jobs, crawl_result for crawl jobs) or the error and expire after the configured TTL.
+// @Tags         Analysis
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  models.Job
+// @Failure      404  {object}  models.HTTPError  "Job not found or expired"
+// @Failure      429  {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500  {object}  models.HTTPError  "Internal server error"
+// @Router       /jobs/{id} [get]
+func GetJobHandler(jobService *jobs.JobService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := jobService.Get(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, job)
+	}
+}
+
+// DeleteJobHandler deletes a job, cancelling it if it is still running
+// @Summary      Delete an analysis job
+// @Description  Removes a job. Queued jobs are dropped and running jobs are cancelled.
+// @Tags         Analysis
+// @Param        id   path  string  true  "Job ID"
+// @Success      204  "Job deleted"
+// @Failure      404  {object}  models.HTTPError  "Job not found or expired"
+// @Failure      429  {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500  {object}  models.HTTPError  "Internal server error"
+// @Router       /jobs/{id} [delete]
+func DeleteJobHandler(jobService *jobs.JobService, errorHandler *middleware.ErrorHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := jobService.Delete(c.Request.Context(), c.Param("id")); err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"slices"
+	"strconv"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultLinksPageSize = 50
+	maxLinksPageSize     = 500
+)
+
+var (
+	linkTypes    = []string{models.LinkTypeInternal, models.LinkTypeExternal, models.LinkTypeInvalid}
+	linkScopes   = []string{models.LinkScopeSameHost, models.LinkScopeSameSite, models.LinkScopeExternal}
+	linkStatuses = []string{models.LinkStatusOK, models.LinkStatusBroken, models.LinkStatusUnchecked, models.LinkStatusDisallowed, models.LinkStatusNotChecked}
+)
+
+// linkQuery holds the link report settings of a request: detail mode, recheck, filters and page
+type linkQuery struct {
+	detailed bool
+	recheck  bool
+	linkType string
+	scope    string
+	status   string
+	rel      string
+	page     int
+	pageSize int
+}
+
+// parseLinkQuery reads the links, links_recheck, links_type, links_scope, links_status, links_rel, links_page and links_page_size query parameters
+func parseLinkQuery(c *gin.Context) (linkQuery, error) {
+	query := linkQuery{
+		linkType: c.Query("links_type"),
+		scope:    c.Query("links_scope"),
+		status:   c.Query("links_status"),
+		rel:      c.Query("links_rel"),
+		page:     1,
+		pageSize: defaultLinksPageSize,
+	}
+
+	switch mode := c.Query("links"); mode {
+	case "", "summary":
+	case "detailed":
+		query.detailed = true
+	default:
+		return linkQuery{}, domainerrors.NewInvalidInputError("links", mode, "expected summary or detailed")
+	}
+
+	if raw := c.Query("links_recheck"); raw != "" {
+		recheck, err := strconv.ParseBool(raw)
+		if err != nil {
+			return linkQuery{}, domainerrors.NewInvalidInputError("links_recheck", raw, "expected true or false")
+		}
+		query.recheck = recheck
+	}
+
+	if query.linkType != "" && !slices.Contains(linkTypes, query.linkType) {
+		return linkQuery{}, domainerrors.NewInvalidInputError("links_type", query.linkType, "expected internal, external or invalid")
+	}
+	if query.scope != "" && !slices.Contains(linkScopes, query.scope) {
+		return linkQuery{}, domainerrors.NewInvalidInputError("links_scope", query.scope, "expected same_host, same_site or external")
+	}
+	if query.status != "" && !slices.Contains(linkStatuses, query.status) {
+		return linkQuery{}, domainerrors.NewInvalidInputError("links_status", query.status, "expected ok, broken, unchecked, disallowed or not_checked")
+	}
+
+	if raw := c.Query("links_page"); raw != "" {
+		page, err := strconv.Atoi(raw)
+		if err != nil || page < 1 {
+			return linkQuery{}, domainerrors.NewInvalidInputError("links_page", raw, "expected a positive integer")
+		}
+		query.page = page
+	}
+	if raw := c.Query("links_page_size"); raw != "" {
+		pageSize, err := strconv.Atoi(raw)
+		if err != nil || pageSize < 1 || pageSize > maxLinksPageSize {
+			return linkQuery{}, domainerrors.NewInvalidInputError("links_page_size", raw, "expected an integer from 1 to "+strconv.Itoa(maxLinksPageSize))
+		}
+		query.pageSize = pageSize
+	}
+
+	return query, nil
+}
+
+// apply replaces the full link list of a response with the requested page of matching links
+func (q linkQuery) apply(response *models.AnalysisResponse) {
+	if response.Links == nil || response.Links.Details == nil {
+		return
+	}
+
+	matching := []models.LinkDetail{}
+	for _, link := range response.Links.Details.Items {
+		if q.matches(link) {
+			matching = append(matching, link)
+		}
+	}
+
+	// Pages past the end are checked before multiplying, so a huge links_page can't overflow
+	start := len(matching)
+	if q.page-1 <= len(matching)/q.pageSize {
+		start = min((q.page-1)*q.pageSize, len(matching))
+	}
+	end := min(start+q.pageSize, len(matching))
+	response.Links.Details = &models.LinkDetails{
+		Total:    len(matching),
+		Page:     q.page,
+		PageSize: q.pageSize,
+		Items:    matching[start:end],
+	}
+}
+
+// matches reports whether a link passes the type, scope, status and rel filters
+func (q linkQuery) matches(link models.LinkDetail) bool {
+	if q.linkType != "" && link.Type != q.linkType {
+		return false
+	}
+	if q.scope != "" && link.Scope != q.scope {
+		return false
+	}
+	if q.status != "" && link.Status != q.status {
+		return false
+	}
+	if q.rel != "" && !slices.Contains(link.Rel, q.rel) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"math"
+	"testing"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinkQuery(t *testing.T) {
+	c := newHTMLTestContext(t, "/api/v1/analyze?url=https://example.com", "", "")
+	query, err := parseLinkQuery(c)
+	require.NoError(t, err)
+	assert.Equal(t, linkQuery{page: 1, pageSize: defaultLinksPageSize}, query)
+
+	c = newHTMLTestContext(t, "/api/v1/analyze?links=detailed&links_recheck=true&links_type=external&links_status=broken&links_rel=nofollow&links_page=3&links_page_size=20", "", "")
+	query, err = parseLinkQuery(c)
+	require.NoError(t, err)
+	assert.Equal(t, linkQuery{detailed: true, recheck: true, linkType: "external", status: "broken", rel: "nofollow", page: 3, pageSize: 20}, query)
+
+	for _, target := range []string{
+		"/api/v1/analyze?links=full",
+		"/api/v1/analyze?links_recheck=please",
+		"/api/v1/analyze?links=detailed&links_type=mailto",
+		"/api/v1/analyze?links=detailed&links_scope=subdomain",
+		"/api/v1/analyze?links=detailed&links_status=404",
+		"/api/v1/analyze?links=detailed&links_page=0",
+		"/api/v1/analyze?links=detailed&links_page_size=501",
+	} {
+		_, err := parseLinkQuery(newHTMLTestContext(t, target, "", ""))
+		assert.True(t, domainerrors.IsInputValidationError(err), "%s: got %v", target, err)
+	}
+}
+
+func TestLinkQuery_Apply(t *testing.T) {
+	var items []models.LinkDetail
+	for i := 0; i < 5; i++ {
+		items = append(items, models.LinkDetail{URL: "https://example.com/" + string(rune('a'+i)), Type: models.LinkTypeInternal, Status: models.LinkStatusNotChecked})
+	}
+	items = append(items,
+		models.LinkDetail{URL: "https://other.com/ok", Type: models.LinkTypeExternal, Scope: models.LinkScopeExternal, Status: models.LinkStatusOK, Rel: []string{"nofollow"}},
+		models.LinkDetail{URL: "https://other.com/gone", Type: models.LinkTypeExternal, Scope: models.LinkScopeExternal, Status: models.LinkStatusBroken, Rel: []string{"nofollow", "ugc"}},
+	)
+	newResponse := func() *models.AnalysisResponse {
+		return &models.AnalysisResponse{Links: &models.Links{Details: &models.LinkDetails{Total: len(items), Items: items}}}
+	}
+
+	response := newResponse()
+	linkQuery{detailed: true, page: 2, pageSize: 3}.apply(response)
+	assert.Equal(t, 7, response.Links.Details.Total)
+	assert.Equal(t, items[3:6], response.Links.Details.Items)
+
+	response = newResponse()
+	linkQuery{detailed: true, scope: "external", page: 1, pageSize: 50}.apply(response)
+	assert.Equal(t, items[5:], response.Links.Details.Items)
+
+	response = newResponse()
+	linkQuery{detailed: true, rel: "nofollow", page: 1, pageSize: 50}.apply(response)
+	assert.Equal(t, items[5:], response.Links.Details.Items)
+
+	response = newResponse()
+	linkQuery{detailed: true, linkType: "external", status: "broken", page: 1, pageSize: 50}.apply(response)
+	assert.Equal(t, 1, response.Links.Details.Total)
+	assert.Equal(t, "https://other.com/gone", response.Links.Details.Items[0].URL)
+
+	// A page past the end is empty, not an error
+	response = newResponse()
+	linkQuery{detailed: true, page: 9, pageSize: 50}.apply(response)
+	assert.Equal(t, 7, response.Links.Details.Total)
+	assert.Empty(t, response.Links.Details.Items)
+	assert.NotNil(t, response.Links.Details.Items)
+
+	// A page number whose offset overflows an int is past the end as well
+	response = newResponse()
+	linkQuery{detailed: true, page: math.MaxInt64/4 + 1, pageSize: 4}.apply(response)
+	assert.Empty(t, response.Links.Details.Items)
+	response = newResponse()
+	linkQuery{detailed: true, page: math.MaxInt, pageSize: maxLinksPageSize}.apply(response)
+	assert.Empty(t, response.Links.Details.Items)
+}
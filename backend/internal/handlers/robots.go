@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/steve-phan/page-insight-tool/internal/middleware"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
+	"github.com/steve-phan/page-insight-tool/internal/validation"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RobotsCheckHandler reports whether a URL may be fetched under robots.txt
+// @Summary      Test a URL against robots.txt
+// @Description  Fetches (or reads from cache) the robots.txt of the URL's host and reports whether our user agent may fetch the URL, which rule decided it and any Crawl-delay.
+// @Tags         Analysis
+// @Produce      json
+// @Param        url   query     string  true  "URL to test"  example(https://example.com/private/page)
+// @Success      200   {object}  models.RobotsCheckResponse
+// @Failure      400   {object}  models.HTTPError  "Invalid URL"
+// @Failure      429   {object}  models.HTTPError  "Rate limit exceeded"
+// @Failure      500   {object}  models.HTTPError  "Internal server error"
+// @Router       /robots [get]
+func RobotsCheckHandler(robotsService *robots.RobotsService, errorHandler *middleware.ErrorHandler, urlValidator *validation.URLValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawURL := c.Query("url")
+		if err := urlValidator.ValidateURL(rawURL); err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		response, err := robotsService.Test(c.Request.Context(), rawURL)
+		if err != nil {
+			errorHandler.HandleError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
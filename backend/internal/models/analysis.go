@@ -4,13 +4,32 @@ type AnalysisRequest struct {
 	URL string `json:"url" validate:"required,url" example:"https://www.google.com"`
 }
 
+// AnalysisResponse holds the sections produced by the extractors that ran
+// Sections of extractors that were not selected are nil and left out of the JSON
 type AnalysisResponse struct {
-	HTMLVersion  string   `json:"html_version" example:"HTML5"`
-	PageTitle    string   `json:"page_title" example:"Google"`
-	Headings     Headings `json:"headings"`
-	Links        Links    `json:"links"`
-	HasLoginForm bool     `json:"has_login_form" example:"true"`
-	AnalysisTime int64    `json:"analysis_time_ms" example:"150"`
+	HTMLVersion  *string   `json:"html_version,omitempty" example:"HTML5"`
+	PageTitle    *string   `json:"page_title,omitempty" example:"Google"`
+	Headings     *Headings `json:"headings,omitempty"`
+	Links        *Links    `json:"links,omitempty"`
+	HasLoginForm *bool     `json:"has_login_form,omitempty" example:"true"`
+	AnalysisTime int64     `json:"analysis_time_ms" example:"150"`
+	// Timing breaks AnalysisTime down into fetch, parse and extractor phases
+	Timing *Timing `json:"timing,omitempty"`
+
+	// Response describes the HTTP exchange of the fetched page; absent for submitted HTML
+	Response *HTTPResponse `json:"response,omitempty"`
+	// Redirects is the redirect chain of the fetched page, empty if it was served directly
+	Redirects *Redirects `json:"redirects,omitempty"`
+	// Charset is the encoding the fetched page was decoded from; absent for submitted HTML
+	Charset *Charset `json:"charset,omitempty"`
+
+	// Sections holds the sections of extension extractors, keyed by section name
+	Sections Sections `json:"sections,omitempty" swaggertype:"object"`
+
+	// Partial is set when an extractor failed, panicked or timed out; the other sections are still complete
+	Partial bool `json:"partial" example:"false"`
+	// Diagnostics lists every extractor that ran, so a failed extractor can be told apart from an empty section
+	Diagnostics []ExtractorDiagnostic `json:"diagnostics,omitempty"`
 }
 
 type Headings struct {
@@ -20,10 +39,154 @@ type Headings struct {
 	H4 int `json:"h4" example:"40"`
 	H5 int `json:"h5" example:"50"`
 	H6 int `json:"h6" example:"60"`
+
+	// Outline lists every heading in document order
+	Outline []HeadingEntry `json:"outline,omitempty"`
+	// Issues flags problems with the heading structure; hidden headings are left out of the structure checks
+	Issues []HeadingIssue `json:"issues,omitempty"`
+}
+
+// Heading structure issues
+const (
+	HeadingIssueMissingH1    = "missing_h1"
+	HeadingIssueMultipleH1   = "multiple_h1"
+	HeadingIssueSkippedLevel = "skipped_level" // e.g. an h2 followed by an h4
+	HeadingIssueEmpty        = "empty_heading"
+	HeadingIssueTooLong      = "long_heading"
+	HeadingIssueHidden       = "hidden_heading" // Hidden with the hidden attribute or aria-hidden="true", on itself or an ancestor
+)
+
+// HeadingEntry is one heading of the document outline
+type HeadingEntry struct {
+	Level int    `json:"level" example:"2"`
+	Text  string `json:"text" example:"Pricing"`
+	// Path locates the heading in the DOM, e.g. html > body > main#content > h2:nth-of-type(2)
+	Path   string `json:"path" example:"html > body > main#content > h2:nth-of-type(2)"`
+	Hidden bool   `json:"hidden,omitempty" example:"false"`
+}
+
+// HeadingIssue is a single problem found in the heading structure
+type HeadingIssue struct {
+	Type    string `json:"type" enums:"missing_h1,multiple_h1,skipped_level,empty_heading,long_heading,hidden_heading" example:"skipped_level"`
+	Message string `json:"message" example:"h2 is followed by h4"`
+	// Path is the heading the issue is about; absent for page-level issues like a missing h1
+	Path string `json:"path,omitempty" example:"html > body > main#content > h4"`
 }
 
 type Links struct {
-	Internal     int `json:"internal" example:"10"`
+	// Internal counts links to the page's own site: its host, other subdomains of its registrable domain and first-party hosts
+	Internal int `json:"internal" example:"10"`
+	// SameSite counts the internal links that leave the page's host
+	SameSite     int `json:"same_site" example:"4"`
 	External     int `json:"external" example:"20"`
 	Inaccessible int `json:"inaccessible" example:"30"`
+	// Unchecked counts external links whose check didn't finish before the link check deadline
+	Unchecked int `json:"unchecked" example:"0"`
+
+	// ExternalStats breaks the external link checks down by outcome
+	ExternalStats LinkStats `json:"external_stats"`
+	// InternalStats does the same for internal links; only set when internal links are checked
+	InternalStats *LinkStats `json:"internal_stats,omitempty"`
+
+	// Details lists every link; only present when the detailed link report is requested
+	Details *LinkDetails `json:"details,omitempty"`
+
+	// InternalURLs holds the resolved internal link targets for crawl mode; never serialized
+	InternalURLs []string `json:"-"`
+}
+
+// Link types of the detailed link report
+const (
+	LinkTypeInternal = "internal"
+	LinkTypeExternal = "external"
+	LinkTypeInvalid  = "invalid" // Missing, unparsable or non-HTTP href
+)
+
+// Link scopes of the detailed link report, by how the link's host relates to the page's host
+const (
+	LinkScopeSameHost = "same_host"
+	LinkScopeSameSite = "same_site" // Same registrable domain (eTLD+1), e.g. www.example.com and blog.example.com, or a first-party host
+	LinkScopeExternal = "external"
+)
+
+// Link statuses of the detailed link report
+const (
+	LinkStatusOK         = "ok"
+	LinkStatusBroken     = "broken"
+	LinkStatusUnchecked  = "unchecked"   // The link check deadline passed before it was checked
+	LinkStatusDisallowed = "disallowed"  // robots.txt forbids fetching it, so it wasn't checked
+	LinkStatusNotChecked = "not_checked" // Internal links, unless internal link checks are enabled
+)
+
+// LinkStats counts the check outcomes of one link type; every anchor is counted, like the link counts
+type LinkStats struct {
+	OK         int `json:"ok" example:"18"`
+	Broken     int `json:"broken" example:"2"`
+	Unchecked  int `json:"unchecked" example:"0"`
+	Disallowed int `json:"disallowed" example:"1"`
+	// Redirected counts checked links that ended up at a different URL
+	Redirected int `json:"redirected" example:"3"`
+}
+
+// LinkDetails is one page of the detailed link report
+type LinkDetails struct {
+	// Total is the number of links matching the filters, across all pages
+	Total    int          `json:"total" example:"42"`
+	Page     int          `json:"page" example:"1"`
+	PageSize int          `json:"page_size" example:"50"`
+	Items    []LinkDetail `json:"items"`
+}
+
+// LinkDetail describes a single <a> element and the outcome of its check
+type LinkDetail struct {
+	// URL is the resolved link target, or the raw href if it couldn't be resolved
+	URL    string   `json:"url" example:"https://example.com/about"`
+	Text   string   `json:"text" example:"About us"`
+	Rel    []string `json:"rel,omitempty" example:"nofollow,noopener"`
+	Target string   `json:"target,omitempty" example:"_blank"`
+	Type   string   `json:"type" enums:"internal,external,invalid" example:"external"`
+	// Scope refines the type; absent for invalid links
+	Scope  string `json:"scope,omitempty" enums:"same_host,same_site,external" example:"external"`
+	Status string `json:"status" enums:"ok,broken,unchecked,disallowed,not_checked" example:"broken"`
+	// StatusCode is the HTTP status the check ended with, 0 if it got no response
+	StatusCode int `json:"status_code,omitempty" example:"404"`
+	// FinalURL is where the check was redirected to; absent when the link wasn't redirected
+	FinalURL string `json:"final_url,omitempty" example:"https://example.com/about-us"`
+	// Error is the error type of a broken link, e.g. HTTP_NOT_FOUND or NETWORK_DNS
+	Error        string  `json:"error,omitempty" example:"HTTP_NOT_FOUND"`
+	ResponseTime float64 `json:"response_time_ms,omitempty" example:"132.5"`
+	// Cached is set when the status came from the shared link check cache instead of a new check
+	Cached bool `json:"cached,omitempty" example:"true"`
+}
+
+// Charset sources, in the precedence order of the HTML encoding sniffing algorithm
+const (
+	CharsetSourceBOM     = "bom"
+	CharsetSourceHeader  = "header"  // charset parameter of the Content-Type header
+	CharsetSourceMeta    = "meta"    // <meta charset> or <meta http-equiv="Content-Type"> in the first 1024 bytes
+	CharsetSourceDefault = "default" // Nothing declared: UTF-8 if the bytes are valid UTF-8, windows-1252 otherwise
+)
+
+// Charset describes the character encoding of a fetched page; the page is always analyzed as UTF-8
+type Charset struct {
+	Name   string `json:"name" example:"shift_jis"`
+	Source string `json:"source" enums:"bom,header,meta,default" example:"meta"`
+}
+
+// Extractor run outcomes
+const (
+	ExtractorStatusOK      = "ok"
+	ExtractorStatusWarning = "warning"
+	ExtractorStatusError   = "error"   // Returned an error or panicked; its section may be missing or incomplete
+	ExtractorStatusTimeout = "timeout" // Exceeded its time budget; its section is left out
+)
+
+// ExtractorDiagnostic reports how a single extractor run went
+type ExtractorDiagnostic struct {
+	Name     string   `json:"name" example:"links"`
+	Status   string   `json:"status" enums:"ok,warning,error,timeout" example:"warning"`
+	Duration float64  `json:"duration_ms" example:"812.4"`
+	Messages []string `json:"messages,omitempty" example:"inaccessible link https://example.com/old: HTTP 404"`
+	// Implicit is set for an extractor that only ran because a requested one depends on it; its section is left out
+	Implicit bool `json:"implicit,omitempty" example:"false"`
 }
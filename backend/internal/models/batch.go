@@ -0,0 +1,23 @@
+package models
+
+// BatchAnalysisRequest is the JSON body accepted by the batch analysis endpoint
+type BatchAnalysisRequest struct {
+	URLs []string `json:"urls" example:"https://example.com,https://www.google.com"`
+}
+
+// BatchAnalysisResult holds the outcome for a single URL of a batch
+// Exactly one of Result or Error is set
+type BatchAnalysisResult struct {
+	URL    string            `json:"url" example:"https://example.com"`
+	Result *AnalysisResponse `json:"result,omitempty"`
+	Error  *HTTPError        `json:"error,omitempty"`
+}
+
+// BatchAnalysisResponse aggregates per-URL results of a batch analysis
+type BatchAnalysisResponse struct {
+	Results      []BatchAnalysisResult `json:"results"`
+	Total        int                   `json:"total" example:"2"`
+	Succeeded    int                   `json:"succeeded" example:"1"`
+	Failed       int                   `json:"failed" example:"1"`
+	AnalysisTime int64                 `json:"analysis_time_ms" example:"1200"`
+}
@@ -0,0 +1,42 @@
+package models
+
+// CrawlRequest is the JSON body accepted by the crawl endpoint
+// Zero limits fall back to the server defaults; larger limits are capped by them
+type CrawlRequest struct {
+	URL      string   `json:"url" validate:"required,url" example:"https://example.com"`
+	MaxDepth int      `json:"max_depth,omitempty" example:"2"`
+	MaxPages int      `json:"max_pages,omitempty" example:"50"`
+	Include  []string `json:"include,omitempty" example:"/blog/*"`
+	Exclude  []string `json:"exclude,omitempty" example:"/blog/tag/*"`
+}
+
+// CrawlPage holds the outcome for a single crawled page
+// Exactly one of Result or Error is set
+type CrawlPage struct {
+	URL    string            `json:"url" example:"https://example.com/about"`
+	Depth  int               `json:"depth" example:"1"`
+	Result *AnalysisResponse `json:"result,omitempty"`
+	Error  *HTTPError        `json:"error,omitempty"`
+}
+
+// CrawlSummary aggregates the analyses of all successfully crawled pages
+type CrawlSummary struct {
+	PagesAnalyzed      int            `json:"pages_analyzed" example:"48"`
+	PagesFailed        int            `json:"pages_failed" example:"2"`
+	PagesDiscovered    int            `json:"pages_discovered" example:"120"`
+	MaxDepthReached    int            `json:"max_depth_reached" example:"2"`
+	PagesWithLoginForm int            `json:"pages_with_login_form" example:"1"`
+	PagesWithoutTitle  int            `json:"pages_without_title" example:"3"`
+	HTMLVersions       map[string]int `json:"html_versions"`
+	Headings           Headings       `json:"headings"`
+	Links              Links          `json:"links"`
+	AvgAnalysisTime    int64          `json:"avg_analysis_time_ms" example:"180"`
+}
+
+// CrawlReport is the site-level report produced by a crawl
+type CrawlReport struct {
+	SeedURL      string       `json:"seed_url" example:"https://example.com"`
+	Pages        []CrawlPage  `json:"pages"`
+	Summary      CrawlSummary `json:"summary"`
+	AnalysisTime int64        `json:"analysis_time_ms" example:"9500"`
+}
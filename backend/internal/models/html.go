@@ -0,0 +1,8 @@
+package models
+
+// AnalyzeHTMLRequest is the JSON body accepted by the raw HTML analysis endpoint
+type AnalyzeHTMLRequest struct {
+	HTML string `json:"html" validate:"required" example:"<!DOCTYPE html><html><head><title>Intranet</title></head><body><a href=\"/docs\">Docs</a></body></html>"`
+	// BaseURL resolves relative links; without it they are counted as internal
+	BaseURL string `json:"base_url,omitempty" example:"https://intranet.example.com/"`
+}
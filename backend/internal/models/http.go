@@ -0,0 +1,60 @@
+package models
+
+// HTTPResponse describes the final HTTP response that delivered the analyzed page
+type HTTPResponse struct {
+	FinalURL        string `json:"final_url" example:"https://www.example.com/"`
+	StatusCode      int    `json:"status_code" example:"200"`
+	Protocol        string `json:"protocol" example:"HTTP/2.0"`
+	ContentType     string `json:"content_type,omitempty" example:"text/html; charset=utf-8"`
+	ContentEncoding string `json:"content_encoding,omitempty" example:"gzip"`
+	// ContentLength is the declared Content-Length; absent when the server didn't send one
+	ContentLength *int64 `json:"content_length,omitempty" example:"5120"`
+	// TransferSize is the size of the body on the wire, BodySize its size after decompression
+	TransferSize int64 `json:"transfer_size" example:"5120"`
+	BodySize     int64 `json:"body_size" example:"18342"`
+	// Headers holds a fixed set of caching, security and server headers, keyed by canonical name
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// RedirectHop is a single redirect response on the way to the analyzed page
+type RedirectHop struct {
+	URL        string `json:"url" example:"http://example.com/"`
+	StatusCode int    `json:"status_code" example:"301"`
+	Location   string `json:"location" example:"https://www.example.com/"`
+}
+
+// Redirects describes the redirect chain that led to the analyzed page
+type Redirects struct {
+	Hops []RedirectHop `json:"hops"`
+	// Loop is set when the chain revisits a URL, e.g. a cookie check bouncing back to the original page
+	Loop bool `json:"loop" example:"false"`
+	// HTTPSDowngrade is set when a hop leads from https to http
+	HTTPSDowngrade bool `json:"https_downgrade" example:"false"`
+	// MixedWWW is set when a hop switches between the www and the apex host of a domain
+	MixedWWW bool `json:"mixed_www" example:"true"`
+}
+
+// Timing breaks an analysis down into phases; all durations are in milliseconds
+type Timing struct {
+	// Fetch is absent for submitted HTML
+	Fetch *FetchTiming `json:"fetch,omitempty"`
+	Parse float64      `json:"parse_ms" example:"4.2"`
+	// Extraction is the wall time of all extractors, which run concurrently
+	Extraction float64 `json:"extraction_ms" example:"812.9"`
+	// Extractors holds the run time of each extractor by name
+	Extractors map[string]float64 `json:"extractors_ms"`
+}
+
+// FetchTiming breaks a page fetch down into network phases
+// Connection phases are summed over all redirect hops and are zero for reused connections
+type FetchTiming struct {
+	DNSLookup    float64 `json:"dns_lookup_ms" example:"12.5"`
+	TCPConnect   float64 `json:"tcp_connect_ms" example:"20.1"`
+	TLSHandshake float64 `json:"tls_handshake_ms" example:"45.3"`
+	// TimeToFirstByte is the wait between sending each request and the first byte of its response
+	TimeToFirstByte float64 `json:"ttfb_ms" example:"180.7"`
+	// ContentDownload is the time from the first byte of the final response until its body was read
+	ContentDownload float64 `json:"download_ms" example:"35.2"`
+	// Total is the whole fetch, including redirects and robots.txt checks
+	Total float64 `json:"total_ms" example:"301.4"`
+}
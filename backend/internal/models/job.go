@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// JobStatus represents the lifecycle state of an asynchronous analysis job
+type JobStatus string
+
+const (
+	JobStatusQueued  JobStatus = "queued"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDone    JobStatus = "done"
+	JobStatusFailed  JobStatus = "failed"
+)
+
+// JobType is the kind of work a job runs
+type JobType string
+
+const (
+	JobTypeAnalyze JobType = "analyze"
+	JobTypeBatch   JobType = "batch"
+	JobTypeCrawl   JobType = "crawl"
+)
+
+// CreateJobRequest is the JSON body accepted when enqueuing an analysis job
+type CreateJobRequest struct {
+	URL string `json:"url" validate:"required,url" example:"https://www.google.com"`
+}
+
+// Job describes an asynchronous job and, once finished, its outcome
+// Analyze jobs have a URL and a Result, batch jobs have URLs and a BatchResult,
+// crawl jobs have a Crawl request and a CrawlResult
+type Job struct {
+	ID          string                 `json:"id" example:"3f6c2a9e-8d1b-4c7a-9f0e-2b5d7c1a4e8f"`
+	Type        JobType                `json:"type" enums:"analyze,batch,crawl" example:"analyze"`
+	URL         string                 `json:"url,omitempty" example:"https://www.google.com"`
+	URLs        []string               `json:"urls,omitempty" example:"https://example.com,https://www.google.com"`
+	Crawl       *CrawlRequest          `json:"crawl,omitempty"`
+	Status      JobStatus              `json:"status" example:"queued"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+	Result      *AnalysisResponse      `json:"result,omitempty"`
+	BatchResult *BatchAnalysisResponse `json:"batch_result,omitempty"`
+	CrawlResult *CrawlReport           `json:"crawl_result,omitempty"`
+	Error       *HTTPError             `json:"error,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// RobotsCheckResponse reports whether a URL may be fetched according to robots.txt
+type RobotsCheckResponse struct {
+	URL        string  `json:"url" example:"https://example.com/private/page"`
+	RobotsURL  string  `json:"robots_url" example:"https://example.com/robots.txt"`
+	UserAgent  string  `json:"user_agent" example:"PageInsightTool"`
+	Allowed    bool    `json:"allowed" example:"false"`
+	Group      string  `json:"group,omitempty" example:"*"`
+	Rule       string  `json:"rule,omitempty" example:"Disallow: /private"`
+	CrawlDelay float64 `json:"crawl_delay_seconds,omitempty" example:"2"`
+	Status     string  `json:"robots_status" example:"fetched" enums:"fetched,missing,unreachable,error"`
+}
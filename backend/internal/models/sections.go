@@ -0,0 +1,29 @@
+package models
+
+// Sections holds the extension sections of an analysis, keyed by section name
+// Each section is owned by one extractor, which declares its name and type with a SectionSchema
+type Sections map[string]any
+
+// SectionSchema describes an extension section for the response and the API docs
+type SectionSchema struct {
+	// Name is the key of the section under "sections"
+	Name        string
+	Description string
+	// Type is a value of the section's Go type; its OpenAPI schema is derived from it by reflection
+	Type any
+}
+
+// SetSection stores the value of a section on the result
+func SetSection[T any](r *AnalysisResponse, name string, value T) {
+	if r.Sections == nil {
+		r.Sections = Sections{}
+	}
+	r.Sections[name] = value
+}
+
+// GetSection returns the value of a section if it is present with the given type
+// Sections of results decoded from JSON are generic maps and won't match a typed lookup
+func GetSection[T any](r *AnalysisResponse, name string) (T, bool) {
+	value, ok := r.Sections[name].(T)
+	return value, ok
+}
@@ -0,0 +1,237 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/swaggo/swag"
+)
+
+// responseDefinition is the generated definition whose "sections" property lists the extension sections
+const responseDefinition = "models.AnalysisResponse"
+
+// Spec serves a generated swagger document extended with the schemas of the extension sections
+// Sections are declared at runtime by the registered extractors, so swag can't see them
+type Spec struct {
+	base     swag.Swagger
+	sections []models.SectionSchema
+
+	once sync.Once
+	doc  string
+}
+
+// NewSpec creates a spec that adds the given sections to the base document
+func NewSpec(base swag.Swagger, sections []models.SectionSchema) *Spec {
+	return &Spec{
+		base:     base,
+		sections: sections,
+	}
+}
+
+// ReadDoc returns the extended document; if it can't be extended, the base document is returned
+func (s *Spec) ReadDoc() string {
+	s.once.Do(func() {
+		doc, err := s.build()
+		if err != nil {
+			log.Printf("Failed to add extension sections to the API docs: %v", err)
+			doc = s.base.ReadDoc()
+		}
+		s.doc = doc
+	})
+	return s.doc
+}
+
+// build patches the section schemas into the base document
+func (s *Spec) build() (string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(s.base.ReadDoc()), &doc); err != nil {
+		return "", fmt.Errorf("parse base document: %w", err)
+	}
+
+	definitions, _ := doc["definitions"].(map[string]any)
+	if definitions == nil {
+		return "", fmt.Errorf("base document has no definitions")
+	}
+	response, _ := definitions[responseDefinition].(map[string]any)
+	properties, _ := response["properties"].(map[string]any)
+	if properties == nil {
+		return "", fmt.Errorf("base document has no %s properties", responseDefinition)
+	}
+
+	builder := &schemaBuilder{definitions: definitions}
+	sectionProperties := make(map[string]any, len(s.sections))
+	for _, section := range s.sections {
+		schema := builder.schema(reflect.TypeOf(section.Type))
+		if section.Description != "" {
+			schema = map[string]any{
+				"description": section.Description,
+				"allOf":       []any{schema},
+			}
+		}
+		sectionProperties[section.Name] = schema
+	}
+
+	sectionsSchema := map[string]any{"type": "object"}
+	if existing, ok := properties["sections"].(map[string]any); ok {
+		sectionsSchema = existing
+	}
+	sectionsSchema["properties"] = sectionProperties
+	properties["sections"] = sectionsSchema
+
+	out, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return "", fmt.Errorf("encode document: %w", err)
+	}
+	return string(out), nil
+}
+
+// schemaBuilder derives swagger schemas from Go types, adding named structs as definitions
+type schemaBuilder struct {
+	definitions map[string]any
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema returns the schema of a type, referencing named structs by definition
+func (b *schemaBuilder) schema(t reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": b.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return b.object(t)
+		}
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, exists := b.definitions[name]; !exists {
+			// Reserve the name first so self-referencing types terminate
+			b.definitions[name] = map[string]any{}
+			b.definitions[name] = b.object(t)
+		}
+		return map[string]any{"$ref": "#/definitions/" + name}
+	default:
+		return map[string]any{}
+	}
+}
+
+// object returns the schema of a struct from its exported, JSON-encoded fields
+func (b *schemaBuilder) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	b.fields(t, properties)
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+// fields adds the properties of a struct's fields, flattening embedded structs like encoding/json
+func (b *schemaBuilder) fields(t reflect.Type, properties map[string]any) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				b.fields(embedded, properties)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := b.schema(field.Type)
+		if _, isRef := schema["$ref"]; !isRef {
+			annotate(schema, field)
+		}
+		properties[name] = schema
+	}
+}
+
+// annotate adds the enums and example tags of a field to its schema
+func annotate(schema map[string]any, field reflect.StructField) {
+	kind, _ := schema["type"].(string)
+	target := schema
+	if kind == "array" {
+		target, _ = schema["items"].(map[string]any)
+		kind, _ = target["type"].(string)
+	}
+
+	if enums, ok := field.Tag.Lookup("enums"); ok {
+		var values []any
+		for _, enum := range strings.Split(enums, ",") {
+			values = append(values, tagValue(kind, enum))
+		}
+		target["enum"] = values
+	}
+	if example, ok := field.Tag.Lookup("example"); ok {
+		if schema["type"] == "array" {
+			var values []any
+			for _, item := range strings.Split(example, ",") {
+				values = append(values, tagValue(kind, item))
+			}
+			schema["example"] = values
+		} else {
+			schema["example"] = tagValue(kind, example)
+		}
+	}
+}
+
+// tagValue converts a tag value to the schema type, keeping it as a string if it doesn't parse
+func tagValue(kind, value string) any {
+	switch kind {
+	case "integer":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+)
+
+type staticDoc string
+
+func (d staticDoc) ReadDoc() string {
+	return string(d)
+}
+
+const baseDoc = `{
+	"swagger": "2.0",
+	"definitions": {
+		"models.AnalysisResponse": {
+			"type": "object",
+			"properties": {
+				"page_title": {"type": "string"},
+				"sections": {"description": "Extension sections", "type": "object"}
+			}
+		}
+	}
+}`
+
+type testAuthor struct {
+	Name string `json:"name" example:"Ada"`
+}
+
+type testSection struct {
+	Score     int          `json:"score" example:"3"`
+	Level     string       `json:"level" enums:"low,high"`
+	Tags      []string     `json:"tags,omitempty" example:"a,b"`
+	Author    *testAuthor  `json:"author"`
+	Authors   []testAuthor `json:"authors"`
+	CheckedAt time.Time    `json:"checked_at"`
+	Internal  string       `json:"-"`
+	hidden    string
+}
+
+func readDefinitions(t *testing.T, doc string) map[string]any {
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal([]byte(doc), &parsed))
+	return parsed["definitions"].(map[string]any)
+}
+
+func TestSpec_AddsSectionSchemas(t *testing.T) {
+	spec := NewSpec(staticDoc(baseDoc), []models.SectionSchema{
+		{Name: "test", Description: "A test section", Type: testSection{}},
+	})
+
+	definitions := readDefinitions(t, spec.ReadDoc())
+
+	sections := definitions["models.AnalysisResponse"].(map[string]any)["properties"].(map[string]any)["sections"].(map[string]any)
+	assert.Equal(t, "Extension sections", sections["description"])
+	test := sections["properties"].(map[string]any)["test"].(map[string]any)
+	assert.Equal(t, "A test section", test["description"])
+	assert.Equal(t, []any{map[string]any{"$ref": "#/definitions/openapi.testSection"}}, test["allOf"])
+
+	properties := definitions["openapi.testSection"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "integer", "example": float64(3)}, properties["score"])
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"low", "high"}}, properties["level"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "example": []any{"a", "b"}}, properties["tags"])
+	assert.Equal(t, map[string]any{"$ref": "#/definitions/openapi.testAuthor"}, properties["author"])
+	assert.Equal(t, map[string]any{"type": "array", "items": map[string]any{"$ref": "#/definitions/openapi.testAuthor"}}, properties["authors"])
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["checked_at"])
+	assert.NotContains(t, properties, "Internal")
+	assert.NotContains(t, properties, "hidden")
+
+	author := definitions["openapi.testAuthor"].(map[string]any)["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "example": "Ada"}, author["name"])
+}
+
+func TestSpec_FallsBackToBaseDocument(t *testing.T) {
+	base := `{"swagger": "2.0"}`
+	spec := NewSpec(staticDoc(base), []models.SectionSchema{
+		{Name: "test", Type: testSection{}},
+	})
+
+	assert.Equal(t, base, spec.ReadDoc())
+}
@@ -54,5 +54,24 @@ func setupAPIRoutes(router *gin.Engine, handlerFactory *handlers.HandlerFactory)
 		analyzeGroup := api.Group("/analyze")
 		analyzeGroup.Use(rateLimiter.RateLimit(5, 10*time.Second))
 		analyzeGroup.GET("", handlerFactory.AnalyzeHandler())
+		analyzeGroup.POST("/html", handlerFactory.AnalyzeHTMLHandler())
+		analyzeGroup.POST("/batch", handlerFactory.BatchAnalyzeHandler())
+
+		// Crawl endpoint: Strictest rate limit (2 requests per minute), each crawl analyzes many pages
+		crawlGroup := api.Group("/crawl")
+		crawlGroup.Use(rateLimiter.RateLimit(2, time.Minute))
+		crawlGroup.POST("", handlerFactory.CrawlHandler())
+
+		// Robots endpoint: Moderate rate limit (30 requests per minute)
+		robotsGroup := api.Group("/robots")
+		robotsGroup.Use(rateLimiter.RateLimit(30, time.Minute))
+		robotsGroup.GET("", handlerFactory.RobotsCheckHandler())
+
+		// Jobs endpoints: Polling-friendly rate limit (60 requests per minute)
+		jobsGroup := api.Group("/jobs")
+		jobsGroup.Use(rateLimiter.RateLimit(60, time.Minute))
+		jobsGroup.POST("", handlerFactory.CreateJobHandler())
+		jobsGroup.GET("/:id", handlerFactory.GetJobHandler())
+		jobsGroup.DELETE("/:id", handlerFactory.DeleteJobHandler())
 	}
 }
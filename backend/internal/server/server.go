@@ -12,6 +12,7 @@ import (
 	"github.com/steve-phan/page-insight-tool/internal/config"
 	"github.com/steve-phan/page-insight-tool/internal/handlers"
 	"github.com/steve-phan/page-insight-tool/internal/memcach"
+	"github.com/steve-phan/page-insight-tool/internal/models"
 	"github.com/steve-phan/page-insight-tool/internal/routes"
 	"github.com/steve-phan/page-insight-tool/internal/services"
 
@@ -69,8 +70,16 @@ func New(cfg *config.Config) (*Server, error) {
 	}, nil
 }
 
+// SectionSchemas returns the extension sections of the configured extractors, for the API docs
+func (s *Server) SectionSchemas() []models.SectionSchema {
+	return s.services.Analyzer.Sections()
+}
+
 // Start starts the server
 func (s *Server) Start() error {
+	// Start background job workers
+	s.services.Jobs.Start()
+
 	// Start server in a goroutine
 	go func() {
 		log.Printf("Starting %s v%s on %s", s.services.Config.App.Name, getVersion(), s.services.Config.GetAddress())
@@ -98,6 +107,9 @@ func (s *Server) Stop() error {
 		return err
 	}
 
+	// Cancel in-flight jobs; they are requeued and run again by the next instance
+	s.services.Jobs.Stop()
+
 	log.Println("Server exited")
 	return nil
 }
@@ -4,25 +4,46 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/steve-phan/page-insight-tool/internal/config"
 	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
 	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/linkcheck"
+	"github.com/steve-phan/page-insight-tool/internal/services/netguard"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 
 	"golang.org/x/net/html"
 )
 
+const (
+	// defaultExtractorTimeout is the per-extractor time budget when none is configured
+	defaultExtractorTimeout = 10 * time.Second
+	// defaultMaxRedirects is how many redirects a fetch follows when none is configured
+	defaultMaxRedirects = 5
+)
+
 // AnalysisOption defines a function that configures an analyzer
 type AnalysisOption func(*AnalyzerConfig)
 
 // AnalyzerConfig holds configuration for the analyzer
 type AnalyzerConfig struct {
 	extractors []Extractor
+	robots     *robots.RobotsService
+}
+
+// RequestOption configures a single analysis request
+type RequestOption func(*requestConfig)
+
+// requestConfig holds per-request analysis settings
+type requestConfig struct {
+	extractors   []string
+	linkDetails  bool
+	recheckLinks bool
 }
 
 // AnalyzerService uses functional options for extensible analysis
@@ -31,6 +52,9 @@ type AnalyzerService struct {
 	httpClient *http.Client
 	userAgent  string
 	config     *AnalyzerConfig
+	registry   *Registry
+
+	extractorTimeout time.Duration
 }
 
 // NewAnalyzerService creates a new analyzer service
@@ -48,12 +72,29 @@ func NewAnalyzerService(cfg *config.Config, options ...AnalysisOption) (*Analyze
 	if len(analyzerConfig.extractors) == 0 {
 		return nil, fmt.Errorf("no extractors configured: at least one extractor must be provided")
 	}
+	registry, err := NewRegistry(analyzerConfig.extractors...)
+	if err != nil {
+		return nil, err
+	}
+
+	extractorTimeout := cfg.Analysis.ExtractorTimeout
+	if extractorTimeout <= 0 {
+		extractorTimeout = defaultExtractorTimeout
+	}
 
+	maxRedirects := cfg.Analysis.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	guard := netguard.NewGuard(cfg)
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: !cfg.Analysis.VerifySSL,
 		},
-		Proxy:                 http.ProxyFromEnvironment,
+		Proxy:                 guard.Proxy,
+		DialContext:           guard.DialContext,
+		ForceAttemptHTTP2:     true, // A custom TLS config otherwise turns HTTP/2 off
 		MaxConnsPerHost:       20,
 		IdleConnTimeout:       30 * time.Second,
 		TLSHandshakeTimeout:   10 * time.Second,
@@ -63,7 +104,7 @@ func NewAnalyzerService(cfg *config.Config, options ...AnalysisOption) (*Analyze
 	client := &http.Client{
 		Timeout:       cfg.Analysis.Timeout * time.Second,
 		Transport:     transport,
-		CheckRedirect: redirectPolicy(5),
+		CheckRedirect: redirectPolicy(maxRedirects, analyzerConfig.robots),
 	}
 
 	return &AnalyzerService{
@@ -71,6 +112,9 @@ func NewAnalyzerService(cfg *config.Config, options ...AnalysisOption) (*Analyze
 		httpClient: client,
 		userAgent:  cfg.App.Name,
 		config:     analyzerConfig,
+		registry:   registry,
+
+		extractorTimeout: extractorTimeout,
 	}, nil
 }
 
@@ -83,77 +127,200 @@ func WithExtractors(extractors ...Extractor) AnalysisOption {
 	}
 }
 
+// WithRobots enforces robots.txt rules on every fetch, including redirect targets
+// A nil service disables enforcement
+func WithRobots(robotsService *robots.RobotsService) AnalysisOption {
+	return func(config *AnalyzerConfig) {
+		config.robots = robotsService
+	}
+}
+
+// Request Options
+
+// WithSelectedExtractors runs only the named extractors; sections of the others are left out
+// No names runs every configured extractor
+func WithSelectedExtractors(names ...string) RequestOption {
+	return func(config *requestConfig) {
+		config.extractors = names
+	}
+}
+
+// WithLinkDetails keeps the full per-link list in the links section; without it only the counts are returned
+func WithLinkDetails() RequestOption {
+	return func(config *requestConfig) {
+		config.linkDetails = true
+	}
+}
+
+// WithLinkRecheck checks every link again instead of using cached link check results
+func WithLinkRecheck() RequestOption {
+	return func(config *requestConfig) {
+		config.recheckLinks = true
+	}
+}
+
+// Sections returns the extension sections of the configured extractors, for the API docs
+func (s *AnalyzerService) Sections() []models.SectionSchema {
+	return s.registry.Sections()
+}
+
 // Analyze performs HTML analysis using configured extractors
-func (s *AnalyzerService) Analyze(ctx context.Context, rawURL string) (models.AnalysisResponse, error) {
+func (s *AnalyzerService) Analyze(ctx context.Context, rawURL string, options ...RequestOption) (models.AnalysisResponse, error) {
 	start := time.Now()
 
+	reqConfig := newRequestConfig(options)
+	extractors, err := s.registry.Select(reqConfig.extractors)
+	if err != nil {
+		return models.AnalysisResponse{}, err
+	}
+
 	u, err := normalizeURL(rawURL)
 	if err != nil {
 		return models.AnalysisResponse{}, err
 	}
 
-	htmlContent, err := s.fetchHTML(ctx, u)
+	page, err := s.fetchHTML(ctx, u)
 	if err != nil {
 		return models.AnalysisResponse{}, err
 	}
 
-	result, err := s.analyzeHTML(htmlContent, u)
+	// Links resolve against the URL the page was served from, after redirects
+	base := u
+	if page.response != nil {
+		if final, err := url.Parse(page.response.FinalURL); err == nil && final.Host != "" {
+			base = final
+		}
+	}
+
+	result, err := s.analyzeHTML(reqConfig.context(ctx), page.html, base, reqConfig.implicit(extractors), extractors...)
 	if err != nil {
 		return models.AnalysisResponse{}, err
 	}
+	result.Charset = page.charset
+	result.Response = page.response
+	result.Redirects = page.redirects
+	result.Timing.Fetch = page.timing
+	reqConfig.trim(&result)
 
 	result.AnalysisTime = int64(time.Since(start) / time.Millisecond)
 	return result, nil
 }
 
-// analyzeHTML performs analysis using configured extractors
-func (s *AnalyzerService) analyzeHTML(raw string, base *url.URL) (models.AnalysisResponse, error) {
-	doc, err := html.Parse(strings.NewReader(raw))
+// AnalyzeHTML analyzes a submitted HTML document without fetching anything
+// The optional base URL resolves relative links; without it relative links count as internal
+func (s *AnalyzerService) AnalyzeHTML(ctx context.Context, raw string, baseURL string, options ...RequestOption) (models.AnalysisResponse, error) {
+	start := time.Now()
+
+	reqConfig := newRequestConfig(options)
+	extractors, err := s.registry.Select(reqConfig.extractors)
 	if err != nil {
-		return models.AnalysisResponse{}, domainerrors.NewHTMLParseError(base.String(), err)
+		return models.AnalysisResponse{}, err
 	}
 
-	result := models.AnalysisResponse{
-		Links:    models.Links{},
-		Headings: models.Headings{},
+	if strings.TrimSpace(raw) == "" {
+		return models.AnalysisResponse{}, domainerrors.NewInvalidInputError("html", nil, "HTML document is required")
+	}
+	if maxSize := s.MaxBodySize(); int64(len(raw)) > maxSize {
+		return models.AnalysisResponse{}, domainerrors.NewContentTooBigError(baseURL, int64(len(raw)), maxSize)
+	}
+
+	base := &url.URL{}
+	if baseURL != "" {
+		u, err := normalizeURL(baseURL)
+		if err != nil {
+			return models.AnalysisResponse{}, err
+		}
+		base = u
 	}
 
-	// Run all configured extractors
-	for _, extractor := range s.config.extractors {
-		extractor.Extract(doc, base, &result, raw)
+	result, err := s.analyzeHTML(reqConfig.context(ctx), raw, base, reqConfig.implicit(extractors), extractors...)
+	if err != nil {
+		return models.AnalysisResponse{}, err
 	}
+	reqConfig.trim(&result)
 
+	result.AnalysisTime = int64(time.Since(start) / time.Millisecond)
 	return result, nil
 }
 
-// fetchHTML fetches HTML content (shared implementation)
-func (s *AnalyzerService) fetchHTML(ctx context.Context, u *url.URL) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	if err != nil {
-		return "", domainerrors.ClassifyNetworkError(u.String(), err)
+// MaxBodySize returns the largest HTML document, in bytes, the analyzer accepts
+func (s *AnalyzerService) MaxBodySize() int64 {
+	return s.cfg.Analysis.MaxBodySize * 1024 * 1024
+}
+
+// newRequestConfig applies the request options
+func newRequestConfig(options []RequestOption) *requestConfig {
+	reqConfig := &requestConfig{}
+	for _, option := range options {
+		option(reqConfig)
 	}
-	req.Header.Set("User-Agent", s.userAgent)
-	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	return reqConfig
+}
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return "", domainerrors.ClassifyNetworkError(u.String(), err)
+// context applies the request settings carried by the context
+func (c *requestConfig) context(ctx context.Context) context.Context {
+	if c.recheckLinks {
+		ctx = linkcheck.WithRecheck(ctx)
+	}
+	return ctx
+}
+
+// implicit returns the names of the selected extractors that only run as a dependency of a requested one
+func (c *requestConfig) implicit(extractors []Extractor) map[string]bool {
+	if len(c.extractors) == 0 {
+		return nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", domainerrors.ClassifyHTTPStatusError(u.String(), resp.StatusCode)
+	implicit := make(map[string]bool)
+	for _, extractor := range extractors {
+		if !slices.Contains(c.extractors, extractor.Name()) {
+			implicit[extractor.Name()] = true
+		}
 	}
+	return implicit
+}
 
-	bodyReader := io.LimitReader(resp.Body, int64(s.cfg.Analysis.MaxBodySize)*1024*1024+1)
-	data, err := io.ReadAll(bodyReader)
+// trim drops the parts of a result the request didn't ask for
+func (c *requestConfig) trim(result *models.AnalysisResponse) {
+	if !c.linkDetails && result.Links != nil {
+		result.Links.Details = nil
+	}
+}
+
+// analyzeHTML performs analysis using the given extractors, or all configured extractors if none are given
+// The sections of implicit extractors are left out of the result
+func (s *AnalyzerService) analyzeHTML(ctx context.Context, raw string, base *url.URL, implicit map[string]bool, extractors ...Extractor) (models.AnalysisResponse, error) {
+	if len(extractors) == 0 {
+		extractors = s.config.extractors
+	}
+
+	parseStart := time.Now()
+	doc, err := html.Parse(strings.NewReader(raw))
 	if err != nil {
-		return "", domainerrors.ClassifyNetworkError(u.String(), err)
+		return models.AnalysisResponse{}, domainerrors.NewHTMLParseError(base.String(), err)
 	}
-	if len(data) > int(s.cfg.Analysis.MaxBodySize*1024*1024) {
-		return "", domainerrors.NewContentTooBigError(u.String(), int64(len(data)), int64(s.cfg.Analysis.MaxBodySize*1024*1024))
+	parseTime := time.Since(parseStart)
+
+	result := models.AnalysisResponse{}
+
+	extractionStart := time.Now()
+	result.Diagnostics = runExtractors(ctx, extractors, doc, base, &result, raw, s.extractorTimeout, implicit)
+	result.Timing = &models.Timing{
+		Parse:      milliseconds(parseTime),
+		Extraction: milliseconds(time.Since(extractionStart)),
+		Extractors: make(map[string]float64, len(result.Diagnostics)),
 	}
-	return string(data), nil
+	for _, diagnostic := range result.Diagnostics {
+		result.Timing.Extractors[diagnostic.Name] = diagnostic.Duration
+		if diagnostic.Status == models.ExtractorStatusError || diagnostic.Status == models.ExtractorStatusTimeout {
+			result.Partial = true
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return models.AnalysisResponse{}, domainerrors.NewNetworkTimeoutError(base.String(), err)
+	}
+
+	return result, nil
 }
 
 // Helper functions for analyzer
@@ -180,13 +347,3 @@ func normalizeURL(rawURL string) (*url.URL, error) {
 
 	return u, nil
 }
-
-// redirectPolicy creates a redirect policy function
-func redirectPolicy(maxRedirects int) func(req *http.Request, via []*http.Request) error {
-	return func(req *http.Request, via []*http.Request) error {
-		if len(via) >= maxRedirects {
-			return domainerrors.NewInternalError(fmt.Sprintf("stopped after %d redirects", maxRedirects), nil)
-		}
-		return nil
-	}
-}
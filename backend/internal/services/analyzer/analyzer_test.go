@@ -1,17 +1,35 @@
 package analyzer
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
 	"github.com/steve-phan/page-insight-tool/internal/models"
 	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+	"github.com/steve-phan/page-insight-tool/internal/services/linkcheck"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 
 	"golang.org/x/net/html"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/simplifiedchinese"
 )
 
 func TestAnalyzerService_BasicAnalysis(t *testing.T) {
@@ -46,20 +64,20 @@ func TestAnalyzerService_BasicAnalysis(t *testing.T) {
 	}
 
 	testURL, _ := url.Parse("https://example.com")
-	result, err := service.analyzeHTML(string(htmlContent), testURL)
+	result, err := service.analyzeHTML(context.Background(), string(htmlContent), testURL, nil)
 	if err != nil {
 		t.Fatalf("Analyze failed: %v", err)
 	}
 
 	// Verify basic analysis
-	if result.HTMLVersion != "HTML5" {
+	if result.HTMLVersion == nil || *result.HTMLVersion != "HTML5" {
 		t.Errorf("HTMLVersion = %v, want HTML5", result.HTMLVersion)
 	}
-	if result.PageTitle != "Test Page" {
+	if result.PageTitle == nil || *result.PageTitle != "Test Page" {
 		t.Errorf("PageTitle = %v, want 'Test Page'", result.PageTitle)
 	}
 
-	if !result.HasLoginForm {
+	if result.HasLoginForm == nil || !*result.HasLoginForm {
 		t.Errorf("HasLoginForm = %v, want true", result.HasLoginForm)
 	}
 }
@@ -95,24 +113,24 @@ func TestAnalyzerService_CustomExtractors(t *testing.T) {
 	}
 
 	testURL, _ := url.Parse("https://example.com")
-	result, err := service.analyzeHTML(string(htmlContent), testURL)
+	result, err := service.analyzeHTML(context.Background(), string(htmlContent), testURL, nil)
 	if err != nil {
 		t.Fatalf("Analyze failed: %v", err)
 	}
 
 	// Should have title and headings
-	if result.PageTitle == "" {
+	if result.PageTitle == nil || *result.PageTitle == "" {
 		t.Errorf("PageTitle should not be empty")
 	}
-	if result.Headings.H1 == 0 {
+	if result.Headings == nil || result.Headings.H1 == 0 {
 		t.Errorf("Should have H1 headings")
 	}
 
 	// Should NOT have links or login form (not configured)
-	if result.Links.Internal != 0 || result.Links.External != 0 {
+	if result.Links != nil {
 		t.Errorf("Should not have links when LinksExtractor is not configured")
 	}
-	if result.HasLoginForm {
+	if result.HasLoginForm != nil {
 		t.Errorf("Should not detect login form when LoginFormExtractor is not configured")
 	}
 }
@@ -140,6 +158,867 @@ func TestAnalyzerService_EmptyExtractors(t *testing.T) {
 	}
 }
 
+func TestAnalyzerService_RobotsDisallowed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/robots.txt":
+			fmt.Fprint(w, "User-agent: *\nDisallow: /private\n")
+		case "/old":
+			http.Redirect(w, r, "/private/page", http.StatusFound)
+		default:
+			fmt.Fprint(w, "<!DOCTYPE html><html><head><title>Page</title></head></html>")
+		}
+	}))
+	defer ts.Close()
+
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 10,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	service, err := NewAnalyzerService(cfg,
+		WithExtractors(&extractors.TitleExtractor{}),
+		WithRobots(robots.NewRobotsService(cfg)),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	if _, err := service.Analyze(context.Background(), ts.URL+"/public"); err != nil {
+		t.Errorf("Analyze of allowed page failed: %v", err)
+	}
+
+	for _, path := range []string{"/private/page", "/old"} {
+		_, err := service.Analyze(context.Background(), ts.URL+path)
+		if !domainerrors.IsRobotsDisallowedError(err) {
+			t.Errorf("Analyze(%s) error = %v, want ROBOTS_DISALLOWED", path, err)
+		}
+	}
+}
+
+func TestAnalyzerService_AnalyzeHTML(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	service, err := NewAnalyzerService(cfg,
+		WithExtractors(
+			&extractors.TitleExtractor{},
+			&extractors.LinksExtractor{},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	doc := `<!DOCTYPE html><html><head><title>Intranet</title></head>
+		<body><a href="/docs">Docs</a><a href="https://intranet.example.com/team">Team</a></body></html>`
+
+	// With a base URL, relative and same-host links resolve as internal
+	result, err := service.AnalyzeHTML(context.Background(), doc, "https://intranet.example.com/")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if result.PageTitle == nil || *result.PageTitle != "Intranet" {
+		t.Errorf("PageTitle = %v, want 'Intranet'", result.PageTitle)
+	}
+	if result.Links.Internal != 2 {
+		t.Errorf("Links.Internal = %d, want 2", result.Links.Internal)
+	}
+	if len(result.Links.InternalURLs) != 2 || result.Links.InternalURLs[0] != "https://intranet.example.com/docs" {
+		t.Errorf("InternalURLs = %v, want resolved URLs", result.Links.InternalURLs)
+	}
+
+	// Without a base URL, relative links still count as internal
+	result, err = service.AnalyzeHTML(context.Background(), `<a href="/docs">Docs</a><a href="#top">Top</a>`, "")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML without base URL failed: %v", err)
+	}
+	if result.Links.Internal != 1 || result.Links.Inaccessible != 0 {
+		t.Errorf("Links = %+v, want one internal link", result.Links)
+	}
+	if result.Links.Details != nil {
+		t.Errorf("Links.Details = %+v, want none without WithLinkDetails", result.Links.Details)
+	}
+
+	result, err = service.AnalyzeHTML(context.Background(), doc, "https://intranet.example.com/", WithLinkDetails())
+	if err != nil {
+		t.Fatalf("AnalyzeHTML with link details failed: %v", err)
+	}
+	if result.Links.Details == nil || result.Links.Details.Total != 2 || result.Links.Details.Items[1].URL != "https://intranet.example.com/team" {
+		t.Errorf("Links.Details = %+v, want both links", result.Links.Details)
+	}
+
+	if _, err := service.AnalyzeHTML(context.Background(), "  ", ""); err == nil {
+		t.Errorf("Expected error for empty document")
+	}
+	if _, err := service.AnalyzeHTML(context.Background(), strings.Repeat("a", 1024*1024+1), ""); err == nil {
+		t.Errorf("Expected error for oversized document")
+	}
+}
+
+func TestAnalyzerService_SelectedExtractors(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	service, err := NewAnalyzerService(cfg,
+		WithExtractors(
+			&extractors.TitleExtractor{},
+			&extractors.HeadingsExtractor{},
+			&extractors.LinksExtractor{},
+			&extractors.LoginFormExtractor{},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	doc := `<html><head><title>Selected</title></head><body><h1>Hi</h1><a href="/a">A</a></body></html>`
+
+	result, err := service.AnalyzeHTML(context.Background(), doc, "", WithSelectedExtractors("title", "headings"))
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if result.PageTitle == nil || result.Headings == nil {
+		t.Errorf("Selected sections missing: title %v, headings %v", result.PageTitle, result.Headings)
+	}
+	if result.Links != nil || result.HasLoginForm != nil {
+		t.Errorf("Unselected sections present: links %v, login form %v", result.Links, result.HasLoginForm)
+	}
+
+	// A selected extractor always reports its section, even when nothing was found
+	result, err = service.AnalyzeHTML(context.Background(), doc, "", WithSelectedExtractors("login_form"))
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if result.HasLoginForm == nil || *result.HasLoginForm {
+		t.Errorf("HasLoginForm = %v, want false", result.HasLoginForm)
+	}
+
+	// Unknown names are rejected before anything is fetched
+	_, err = service.Analyze(context.Background(), "https://example.invalid", WithSelectedExtractors("seo"))
+	if !domainerrors.IsInputValidationError(err) {
+		t.Errorf("Analyze with unknown extractor: got %v, want validation error", err)
+	}
+}
+
+// failingExtractor always reports an error, after a warning
+type failingExtractor struct{}
+
+func (e *failingExtractor) Name() string { return "failing" }
+
+func (e *failingExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	return []string{"partial input"}, errors.New("detection failed")
+}
+
+func TestAnalyzerService_Diagnostics(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	service, err := NewAnalyzerService(cfg,
+		WithExtractors(
+			&extractors.TitleExtractor{},
+			&extractors.HeadingsExtractor{},
+			&failingExtractor{},
+		),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	result, err := service.AnalyzeHTML(context.Background(), `<html><body><h1>No title</h1></body></html>`, "")
+	if err != nil {
+		t.Fatalf("An extractor error must not fail the analysis: %v", err)
+	}
+
+	if len(result.Diagnostics) != 3 {
+		t.Fatalf("Diagnostics = %+v, want one entry per extractor", result.Diagnostics)
+	}
+
+	expected := []struct {
+		name     string
+		status   string
+		messages []string
+	}{
+		{"title", models.ExtractorStatusWarning, []string{"no non-empty <title> element found"}},
+		{"headings", models.ExtractorStatusOK, nil},
+		{"failing", models.ExtractorStatusError, []string{"detection failed", "partial input"}},
+	}
+	for i, want := range expected {
+		got := result.Diagnostics[i]
+		if got.Name != want.name || got.Status != want.status || strings.Join(got.Messages, "|") != strings.Join(want.messages, "|") {
+			t.Errorf("Diagnostics[%d] = %+v, want %s/%s %v", i, got, want.name, want.status, want.messages)
+		}
+		if got.Duration < 0 {
+			t.Errorf("Diagnostics[%d].Duration = %v, want >= 0", i, got.Duration)
+		}
+	}
+}
+
+func TestAnalyzerService_ExtractorDependencies(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	// "summary" reads the title, so it must run after the (slow) title extractor
+	slowTitle := &stubExtractor{name: "title", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		time.Sleep(20 * time.Millisecond)
+		title := "Slow Title"
+		result.PageTitle = &title
+		return nil, nil
+	}}
+	summary := &stubExtractor{name: "summary", deps: []string{"title"}, run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		if result.PageTitle == nil {
+			return nil, errors.New("title not available")
+		}
+		version := "summary of " + *result.PageTitle
+		result.HTMLVersion = &version
+		return nil, nil
+	}}
+
+	service, err := NewAnalyzerService(cfg, WithExtractors(summary, slowTitle))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	result, err := service.AnalyzeHTML(context.Background(), "<html></html>", "")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if result.HTMLVersion == nil || *result.HTMLVersion != "summary of Slow Title" {
+		t.Errorf("Dependent extractor ran before its dependency: %+v", result.Diagnostics)
+	}
+}
+
+func TestAnalyzerService_ContextCancellation(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	blocking := &stubExtractor{name: "blocking", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}
+	service, err := NewAnalyzerService(cfg, WithExtractors(blocking, &extractors.TitleExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = service.AnalyzeHTML(ctx, "<html></html>", "")
+	if !domainerrors.IsNetworkError(err) {
+		t.Errorf("AnalyzeHTML after cancellation: got %v, want timeout error", err)
+	}
+}
+
+func TestAnalyzerService_IsolatesFailingExtractors(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:          30,
+			MaxBodySize:      1,
+			ExtractorTimeout: 50 * time.Millisecond,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	panicking := &stubExtractor{name: "panicking", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		version := "half written"
+		result.HTMLVersion = &version
+		var headings *models.Headings
+		headings.H1++ // nil pointer dereference
+		return nil, nil
+	}}
+	slow := &stubExtractor{name: "slow", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		time.Sleep(500 * time.Millisecond) // Ignores its context
+		found := true
+		result.HasLoginForm = &found
+		return nil, nil
+	}}
+
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}, panicking, slow))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	start := time.Now()
+	result, err := service.AnalyzeHTML(context.Background(), "<html><head><title>Still here</title></head></html>", "")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML must not fail because of one extractor: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 400*time.Millisecond {
+		t.Errorf("AnalyzeHTML waited %v for a timed-out extractor", elapsed)
+	}
+
+	if !result.Partial {
+		t.Errorf("Partial = false, want true")
+	}
+	if result.PageTitle == nil || *result.PageTitle != "Still here" {
+		t.Errorf("PageTitle = %v, want the healthy extractor's section", result.PageTitle)
+	}
+	if result.HTMLVersion != nil || result.HasLoginForm != nil {
+		t.Errorf("Sections of failed extractors leaked: version %v, login form %v", result.HTMLVersion, result.HasLoginForm)
+	}
+
+	statuses := map[string]string{}
+	for _, diagnostic := range result.Diagnostics {
+		statuses[diagnostic.Name] = diagnostic.Status
+	}
+	want := map[string]string{
+		"title":     models.ExtractorStatusOK,
+		"panicking": models.ExtractorStatusError,
+		"slow":      models.ExtractorStatusTimeout,
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Errorf("%s status = %q, want %q", name, statuses[name], status)
+		}
+	}
+}
+
+func TestAnalyzerService_LinkCheckDeadlineWithinExtractorBudget(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	// A link check deadline longer than the extractor budget must not cost the links section
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:          30,
+			MaxBodySize:      1,
+			ExtractorTimeout: 300 * time.Millisecond,
+		},
+		LinkCheck: config.LinkCheckConfig{
+			Workers:  1,
+			Timeout:  5 * time.Second,
+			Deadline: 20 * time.Second,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+	links := &extractors.LinksExtractor{Checker: linkcheck.NewChecker(cfg)}
+	service, err := NewAnalyzerService(cfg, WithExtractors(links))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	page := fmt.Sprintf(`<html><body><a href="%[1]s/a">A</a><a href="%[1]s/b">B</a></body></html>`, ts.URL)
+	result, err := service.AnalyzeHTML(context.Background(), page, "https://example.com/")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+
+	if result.Partial {
+		t.Errorf("Partial = true, want the links section reported with unchecked links: %+v", result.Diagnostics)
+	}
+	if result.Links == nil {
+		t.Fatal("Links section was dropped")
+	}
+	if result.Links.Unchecked != 2 {
+		t.Errorf("Unchecked = %d, want 2", result.Links.Unchecked)
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Status != models.ExtractorStatusWarning {
+		t.Errorf("Diagnostics = %+v, want a links warning", result.Diagnostics)
+	}
+}
+
+func TestAnalyzerService_ExtensionSections(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	owner := &sectionExtractor{section: "counts", stubExtractor: stubExtractor{name: "counts", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		models.SetSection(result, "counts", map[string]int{"divs": 2})
+		return nil, nil
+	}}}
+	// Writes to sections an extractor doesn't own are dropped
+	intruder := &stubExtractor{name: "intruder", run: func(ctx context.Context, result *models.AnalysisResponse) ([]string, error) {
+		models.SetSection(result, "counts", map[string]int{"divs": -1})
+		models.SetSection(result, "other", true)
+		return nil, nil
+	}}
+
+	service, err := NewAnalyzerService(cfg, WithExtractors(owner, intruder))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	result, err := service.AnalyzeHTML(context.Background(), "<html></html>", "")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	counts, ok := models.GetSection[map[string]int](&result, "counts")
+	if !ok || counts["divs"] != 2 {
+		t.Errorf("counts section = %v, want the owner's value", result.Sections)
+	}
+	if _, ok := result.Sections["other"]; ok {
+		t.Errorf("Unowned section leaked: %v", result.Sections)
+	}
+}
+
+func TestAnalyzerService_MetaTitleFallback(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}, &extractors.MetaExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	// Selecting meta alone runs the title extractor it depends on, but only returns the meta section
+	doc := `<html><head><meta property="og:title" content="Shared title"></head></html>`
+	result, err := service.AnalyzeHTML(context.Background(), doc, "https://example.com/", WithSelectedExtractors("meta"))
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	meta, ok := models.GetSection[extractors.Meta](&result, "meta")
+	if !ok || meta.Title != "Shared title" || !meta.TitleFallback || meta.Preview.TitleSource != "og:title" {
+		t.Errorf("meta section = %+v, want the og:title fallback and preview", meta)
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("Failed to encode result: %v", err)
+	}
+	var shape map[string]json.RawMessage
+	if err := json.Unmarshal(body, &shape); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if _, ok := shape["page_title"]; ok {
+		t.Errorf("page_title returned though only meta was requested: %s", body)
+	}
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(shape["sections"], &sections); err != nil || len(sections) != 1 || sections["meta"] == nil {
+		t.Errorf("sections = %s, want only meta", shape["sections"])
+	}
+	for _, diagnostic := range result.Diagnostics {
+		if diagnostic.Implicit != (diagnostic.Name == "title") {
+			t.Errorf("diagnostic %+v, want only title marked implicit", diagnostic)
+		}
+	}
+
+	// Requested together, page_title is what the title extractor found and the fallback stays in the meta section
+	result, err = service.AnalyzeHTML(context.Background(), doc, "https://example.com/", WithSelectedExtractors("meta", "title"))
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if result.PageTitle == nil || *result.PageTitle != "" {
+		t.Errorf("PageTitle = %v, want the empty title the title extractor found", result.PageTitle)
+	}
+	if meta, _ := models.GetSection[extractors.Meta](&result, "meta"); meta.Title != "Shared title" {
+		t.Errorf("meta title = %q, want the og:title fallback", meta.Title)
+	}
+	for _, diagnostic := range result.Diagnostics {
+		if diagnostic.Implicit {
+			t.Errorf("diagnostic %+v marked implicit though requested", diagnostic)
+		}
+	}
+}
+
+func TestAnalyzerService_Charset(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	encode := func(enc encoding.Encoding, s string) string {
+		encoded, err := enc.NewEncoder().String(s)
+		if err != nil {
+			t.Fatalf("Failed to encode test page: %v", err)
+		}
+		return encoded
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		title       string
+		charset     models.Charset
+	}{
+		{
+			name:        "Shift_JIS from meta charset",
+			contentType: "text/html",
+			body:        encode(japanese.ShiftJIS, `<html><head><meta charset="Shift_JIS"><title>日本語のページ</title></head></html>`),
+			title:       "日本語のページ",
+			charset:     models.Charset{Name: "shift_jis", Source: models.CharsetSourceMeta},
+		},
+		{
+			name:        "GBK from http-equiv",
+			contentType: "text/html",
+			body:        encode(simplifiedchinese.GBK, `<html><head><meta http-equiv="Content-Type" content="text/html; charset=gbk"><title>中文页面</title></head></html>`),
+			title:       "中文页面",
+			charset:     models.Charset{Name: "gbk", Source: models.CharsetSourceMeta},
+		},
+		{
+			name:        "Header wins over meta",
+			contentType: "text/html; charset=windows-1252",
+			body:        encode(charmap.Windows1252, `<html><head><meta charset="utf-8"><title>Café</title></head></html>`),
+			title:       "Café",
+			charset:     models.Charset{Name: "windows-1252", Source: models.CharsetSourceHeader},
+		},
+		{
+			name:        "BOM wins over header",
+			contentType: "text/html; charset=iso-8859-1",
+			body:        "\xEF\xBB\xBF<html><head><title>Café</title></head></html>",
+			title:       "Café",
+			charset:     models.Charset{Name: "utf-8", Source: models.CharsetSourceBOM},
+		},
+		{
+			name:        "Undeclared Latin-1 falls back to windows-1252",
+			contentType: "text/html",
+			body:        encode(charmap.Windows1252, `<html><head><title>Café</title></head></html>`),
+			title:       "Café",
+			charset:     models.Charset{Name: "windows-1252", Source: models.CharsetSourceDefault},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			result, err := service.Analyze(context.Background(), ts.URL)
+			if err != nil {
+				t.Fatalf("Analyze failed: %v", err)
+			}
+			if result.PageTitle == nil || *result.PageTitle != tt.title {
+				t.Errorf("PageTitle = %v, want %q", result.PageTitle, tt.title)
+			}
+			if result.Charset == nil || *result.Charset != tt.charset {
+				t.Errorf("Charset = %+v, want %+v", result.Charset, tt.charset)
+			}
+		})
+	}
+}
+
+func TestAnalyzerService_ResponseMetadata(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	page := `<html><head><title>Compressed</title></head><body>` + strings.Repeat("<p>Repeated text compresses well.</p>", 100) + `</body></html>`
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(page))
+	gz.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/page", http.StatusMovedPermanently)
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Header().Set("Content-Length", strconv.Itoa(compressed.Len()))
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Add("Vary", "Accept-Encoding")
+			w.Header().Add("Vary", "User-Agent")
+			w.Header().Set("Set-Cookie", "session=secret")
+			w.Write(compressed.Bytes())
+		case "/corrupt":
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write([]byte("not gzip at all"))
+		}
+	}))
+	defer ts.Close()
+
+	result, err := service.Analyze(context.Background(), ts.URL+"/old")
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if result.PageTitle == nil || *result.PageTitle != "Compressed" {
+		t.Errorf("PageTitle = %v, want the decompressed page's title", result.PageTitle)
+	}
+
+	response := result.Response
+	if response == nil {
+		t.Fatalf("Response metadata missing")
+	}
+	if response.FinalURL != ts.URL+"/page" || response.StatusCode != http.StatusOK || response.Protocol != "HTTP/1.1" {
+		t.Errorf("Response = %+v, want final URL, status and protocol of the last hop", response)
+	}
+	if response.ContentType != "text/html; charset=utf-8" || response.ContentEncoding != "gzip" {
+		t.Errorf("Content type/encoding = %q/%q", response.ContentType, response.ContentEncoding)
+	}
+	if response.ContentLength == nil || *response.ContentLength != int64(compressed.Len()) {
+		t.Errorf("ContentLength = %v, want %d", response.ContentLength, compressed.Len())
+	}
+	if response.TransferSize != int64(compressed.Len()) || response.BodySize != int64(len(page)) {
+		t.Errorf("TransferSize/BodySize = %d/%d, want %d/%d", response.TransferSize, response.BodySize, compressed.Len(), len(page))
+	}
+	if response.Headers["Cache-Control"] != "max-age=60" || response.Headers["Vary"] != "Accept-Encoding, User-Agent" {
+		t.Errorf("Headers = %v, want the reported headers", response.Headers)
+	}
+	if _, ok := response.Headers["Set-Cookie"]; ok {
+		t.Errorf("Headers = %v, unlisted headers must not be reported", response.Headers)
+	}
+
+	_, err = service.Analyze(context.Background(), ts.URL+"/corrupt")
+	if !domainerrors.IsContentProcessingError(err) {
+		t.Errorf("Analyze of a corrupt gzip body: got %v, want content processing error", err)
+	}
+}
+
+func TestAnalyzerService_Redirects(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:      30,
+			MaxBodySize:  1,
+			MaxRedirects: 3,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	// The first visit of /start bounces through /check, like a cookie check
+	var checked atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/start":
+			if checked.Load() {
+				w.Write([]byte(`<html><head><title>Landed</title></head></html>`))
+				return
+			}
+			http.Redirect(w, r, "/check", http.StatusFound)
+		case "/check":
+			checked.Store(true)
+			http.Redirect(w, r, "/start", http.StatusTemporaryRedirect)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusMovedPermanently)
+		}
+	}))
+	defer ts.Close()
+
+	t.Run("Chain with loop", func(t *testing.T) {
+		result, err := service.Analyze(context.Background(), ts.URL+"/start")
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if result.Redirects == nil {
+			t.Fatalf("Redirects missing")
+		}
+		want := []models.RedirectHop{
+			{URL: ts.URL + "/start", StatusCode: http.StatusFound, Location: "/check"},
+			{URL: ts.URL + "/check", StatusCode: http.StatusTemporaryRedirect, Location: "/start"},
+		}
+		if fmt.Sprint(result.Redirects.Hops) != fmt.Sprint(want) {
+			t.Errorf("Hops = %v, want %v", result.Redirects.Hops, want)
+		}
+		if !result.Redirects.Loop || result.Redirects.HTTPSDowngrade || result.Redirects.MixedWWW {
+			t.Errorf("Flags = %+v, want only loop", result.Redirects)
+		}
+	})
+
+	t.Run("Too many redirects", func(t *testing.T) {
+		_, err := service.Analyze(context.Background(), ts.URL+"/loop")
+		var domainErr *domainerrors.DomainError
+		if !errors.As(err, &domainErr) || domainErr.Type != domainerrors.ErrorTypeTooManyRedirects {
+			t.Fatalf("Analyze of a redirect loop: got %v, want TOO_MANY_REDIRECTS", err)
+		}
+		if chain, _ := domainErr.Details["chain"].([]string); len(chain) != 5 || domainErr.Details["loop"] != true {
+			t.Errorf("Details = %v, want 4 followed hops plus the refused one, flagged as a loop", domainErr.Details)
+		}
+	})
+
+	t.Run("Direct response", func(t *testing.T) {
+		result, err := service.Analyze(context.Background(), ts.URL+"/start")
+		if err != nil {
+			t.Fatalf("Analyze failed: %v", err)
+		}
+		if result.Redirects == nil || len(result.Redirects.Hops) != 0 || result.Redirects.Loop {
+			t.Errorf("Redirects = %+v, want an empty chain", result.Redirects)
+		}
+	})
+}
+
+func TestAnalyzerService_Timing(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+	}
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}, &extractors.HeadingsExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond) // Server think time
+		w.Write([]byte(`<html><head><title>Slow</title></head>`))
+		w.(http.Flusher).Flush()
+		time.Sleep(20 * time.Millisecond) // Slow download
+		w.Write([]byte(`<body><h1>Done</h1></body></html>`))
+	}))
+	defer ts.Close()
+
+	result, err := service.Analyze(context.Background(), ts.URL)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if result.Timing == nil || result.Timing.Fetch == nil {
+		t.Fatalf("Timing = %+v, want fetch phases", result.Timing)
+	}
+
+	fetch := result.Timing.Fetch
+	if fetch.TCPConnect <= 0 || fetch.TLSHandshake <= 0 {
+		t.Errorf("Connection phases = %+v, want TCP connect and TLS handshake", fetch)
+	}
+	if fetch.TimeToFirstByte < 30 || fetch.ContentDownload < 20 {
+		t.Errorf("TTFB/download = %v/%v ms, want at least 30/20", fetch.TimeToFirstByte, fetch.ContentDownload)
+	}
+	if fetch.Total < fetch.TimeToFirstByte+fetch.ContentDownload {
+		t.Errorf("Total = %v ms, want at least TTFB + download", fetch.Total)
+	}
+	if len(result.Timing.Extractors) != 2 || result.Timing.Extraction <= 0 {
+		t.Errorf("Extractor timing = %v (%v ms), want both extractors", result.Timing.Extractors, result.Timing.Extraction)
+	}
+
+	// Submitted HTML has no fetch phases
+	submitted, err := service.AnalyzeHTML(context.Background(), "<html></html>", "")
+	if err != nil {
+		t.Fatalf("AnalyzeHTML failed: %v", err)
+	}
+	if submitted.Timing == nil || submitted.Timing.Fetch != nil {
+		t.Errorf("Timing = %+v, want parse and extractor phases only", submitted.Timing)
+	}
+}
+
+func TestAnalyzerService_BlocksInternalDestinations(t *testing.T) {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 1,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+		Security: config.SecurityConfig{
+			BlockPrivateNetworks: true,
+		},
+	}
+	service, err := NewAnalyzerService(cfg, WithExtractors(&extractors.TitleExtractor{}))
+	if err != nil {
+		t.Fatalf("Failed to create analyzer service: %v", err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Blocked destination was requested: %s", r.URL)
+	}))
+	defer ts.Close()
+
+	_, err = service.Analyze(context.Background(), ts.URL)
+	if !domainerrors.IsDestinationBlockedError(err) {
+		t.Errorf("Analyze of a loopback URL: got %v, want DESTINATION_BLOCKED", err)
+	}
+}
+
+func TestRedirectChain_Summary(t *testing.T) {
+	chain := &redirectChain{hops: []models.RedirectHop{
+		{URL: "https://example.com/", StatusCode: 301, Location: "https://www.example.com/"},
+		{URL: "https://www.example.com/", StatusCode: 302, Location: "http://www.example.com/home"},
+	}}
+	final, _ := url.Parse("http://www.example.com/home")
+
+	summary := chain.summary(final)
+	if summary.Loop || !summary.HTTPSDowngrade || !summary.MixedWWW {
+		t.Errorf("Flags = %+v, want downgrade and mixed www", summary)
+	}
+
+	if isWWWSwitch("www.example.com", "www.example.org") || isWWWSwitch("example.com", "example.com") {
+		t.Errorf("isWWWSwitch flagged hosts that aren't www/apex variants")
+	}
+}
+
 // Test individual extractors
 func TestTitleExtractor(t *testing.T) {
 	extractor := &extractors.TitleExtractor{}
@@ -153,8 +1032,8 @@ func TestTitleExtractor(t *testing.T) {
 		t.Fatalf("Failed to parse HTML: %v", err)
 	}
 
-	extractor.Extract(doc, testURL, result, htmlContent)
-	if result.PageTitle != "Test Title" {
+	extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
+	if result.PageTitle == nil || *result.PageTitle != "Test Title" {
 		t.Errorf("TitleExtractor failed: got %v, want 'Test Title'", result.PageTitle)
 	}
 }
@@ -171,9 +1050,9 @@ func TestHeadingsExtractor(t *testing.T) {
 		t.Fatalf("Failed to parse HTML: %v", err)
 	}
 
-	extractor.Extract(doc, testURL, result, htmlContent)
+	extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
 	expected := models.Headings{H1: 1, H2: 1, H3: 1}
-	if result.Headings != expected {
+	if result.Headings == nil || result.Headings.H1 != expected.H1 || result.Headings.H2 != expected.H2 || result.Headings.H3 != expected.H3 {
 		t.Errorf("HeadingsExtractor failed: got %+v, want %+v", result.Headings, expected)
 	}
 }
@@ -190,8 +1069,8 @@ func TestLoginFormExtractor(t *testing.T) {
 		t.Fatalf("Failed to parse HTML: %v", err)
 	}
 
-	extractor.Extract(doc, testURL, result, htmlContent)
-	if !result.HasLoginForm {
+	extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
+	if result.HasLoginForm == nil || !*result.HasLoginForm {
 		t.Errorf("LoginFormExtractor failed: should detect login form")
 	}
 }
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"bytes"
+	"mime"
+	"strings"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+)
+
+// metaPrescanBytes is how far into the document a <meta> charset declaration is looked for
+const metaPrescanBytes = 1024
+
+// byteOrderMarks maps each BOM to the encoding it announces
+var byteOrderMarks = []struct {
+	prefix []byte
+	label  string
+}{
+	{[]byte{0xEF, 0xBB, 0xBF}, "utf-8"},
+	{[]byte{0xFE, 0xFF}, "utf-16be"},
+	{[]byte{0xFF, 0xFE}, "utf-16le"},
+}
+
+// decodeHTML transcodes a fetched document to UTF-8 and reports the charset it was in
+func decodeHTML(data []byte, contentType string) (string, *models.Charset, error) {
+	enc, detected := sniffCharset(data, contentType)
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimPrefix(string(decoded), "\uFEFF"), detected, nil
+}
+
+// sniffCharset picks the encoding like the HTML5 sniffing algorithm: the BOM wins over the
+// Content-Type header, which wins over a <meta> declaration; otherwise the encoding is guessed
+// Labels are resolved with the WHATWG encoding names, so e.g. iso-8859-1 decodes as windows-1252
+func sniffCharset(data []byte, contentType string) (encoding.Encoding, *models.Charset) {
+	for _, bom := range byteOrderMarks {
+		if bytes.HasPrefix(data, bom.prefix) {
+			enc, name := charset.Lookup(bom.label)
+			return enc, &models.Charset{Name: name, Source: models.CharsetSourceBOM}
+		}
+	}
+
+	// Unknown labels in the header are ignored, as browsers do
+	if _, params, err := mime.ParseMediaType(contentType); err == nil {
+		if enc, name := charset.Lookup(params["charset"]); enc != nil {
+			return enc, &models.Charset{Name: name, Source: models.CharsetSourceHeader}
+		}
+	}
+
+	if enc, name := charset.Lookup(metaCharset(data)); enc != nil {
+		// A page can't declare itself UTF-16 from inside its own bytes
+		if strings.HasPrefix(name, "utf-16") {
+			enc, name = charset.Lookup("utf-8")
+		}
+		return enc, &models.Charset{Name: name, Source: models.CharsetSourceMeta}
+	}
+
+	enc, name, _ := charset.DetermineEncoding(data, "")
+	return enc, &models.Charset{Name: name, Source: models.CharsetSourceDefault}
+}
+
+// metaCharset returns the charset label declared by a <meta> element in the first 1024 bytes
+func metaCharset(data []byte) string {
+	if len(data) > metaPrescanBytes {
+		data = data[:metaPrescanBytes]
+	}
+
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.Meta {
+				continue
+			}
+			var httpEquiv, content string
+			for _, attr := range token.Attr {
+				switch strings.ToLower(attr.Key) {
+				case "charset":
+					return strings.TrimSpace(attr.Val)
+				case "http-equiv":
+					httpEquiv = strings.ToLower(attr.Val)
+				case "content":
+					content = attr.Val
+				}
+			}
+			if httpEquiv == "content-type" {
+				if _, params, err := mime.ParseMediaType(content); err == nil && params["charset"] != "" {
+					return params["charset"]
+				}
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package analyzer
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/steve-phan/page-insight-tool/internal/models"
@@ -15,6 +16,26 @@ type Extractor interface {
 	Name() string
 
 	// Extract processes the HTML document and populates the result with extracted data
+	// Extractors run concurrently, so each one may only write its own section of the result
+	// and must stop early once ctx is cancelled
+	// Values read from the result are shared with other extractors and must not be modified;
+	// a section is written by storing a newly allocated value
+	// Warnings describe problems that didn't stop extraction; an error means the section is unreliable
 	// The extractor should be idempotent and safe to call multiple times
-	Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string)
+	Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) (warnings []string, err error)
+}
+
+// Dependent is implemented by extractors that read another extractor's section
+// The runner starts a dependent extractor only after all of its dependencies finished
+type Dependent interface {
+	// DependsOn returns the names of the extractors whose output this extractor reads
+	DependsOn() []string
+}
+
+// SectionOwner is implemented by extractors that write an extension section
+// The extractor stores its section with models.SetSection under the declared name;
+// writes to sections it doesn't own are discarded
+type SectionOwner interface {
+	// Section describes the section for the response and the API docs
+	Section() models.SectionSchema
 }
@@ -0,0 +1,328 @@
+package extractors
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+// accessibilitySection is the name of the section the accessibility extractor writes
+const accessibilitySection = "accessibility"
+
+// Accessibility rules
+const (
+	ruleImageAlt         = "image_alt"
+	ruleFormLabel        = "form_label"
+	ruleHTMLLang         = "html_lang"
+	ruleButtonName       = "button_name"
+	ruleLinkName         = "link_name"
+	ruleGenericLinkText  = "generic_link_text"
+	ruleDuplicateID      = "duplicate_id"
+	ruleARIARole         = "aria_role"
+	ruleARIAAttribute    = "aria_attribute"
+	rulePositiveTabindex = "positive_tabindex"
+)
+
+// wcagCriterion is the WCAG 2.2 success criterion a rule checks
+type wcagCriterion struct {
+	id    string
+	level string
+}
+
+var ruleCriteria = map[string]wcagCriterion{
+	ruleImageAlt:         {"1.1.1", "A"}, // Non-text Content
+	ruleFormLabel:        {"4.1.2", "A"}, // Name, Role, Value
+	ruleHTMLLang:         {"3.1.1", "A"}, // Language of Page
+	ruleButtonName:       {"4.1.2", "A"},
+	ruleLinkName:         {"2.4.4", "A"}, // Link Purpose (In Context)
+	ruleGenericLinkText:  {"2.4.4", "A"},
+	ruleDuplicateID:      {"4.1.2", "A"},
+	ruleARIARole:         {"4.1.2", "A"},
+	ruleARIAAttribute:    {"4.1.2", "A"},
+	rulePositiveTabindex: {"2.4.3", "A"}, // Focus Order
+}
+
+// genericLinkTexts are link names that say nothing about the target out of context
+var genericLinkTexts = []string{"click here", "click", "here", "read more", "more", "learn more", "more info", "details", "link", "this", "go"}
+
+// ariaRoles are the non-abstract WAI-ARIA 1.2 roles; doc-* and graphics-* roles are accepted by prefix
+var ariaRoles = []string{
+	"alert", "alertdialog", "application", "article", "banner", "blockquote", "button", "caption", "cell", "checkbox",
+	"code", "columnheader", "combobox", "complementary", "contentinfo", "definition", "deletion", "dialog", "directory",
+	"document", "emphasis", "feed", "figure", "form", "generic", "grid", "gridcell", "group", "heading", "img",
+	"insertion", "link", "list", "listbox", "listitem", "log", "main", "marquee", "math", "menu", "menubar", "menuitem",
+	"menuitemcheckbox", "menuitemradio", "meter", "navigation", "none", "note", "option", "paragraph", "presentation",
+	"progressbar", "radio", "radiogroup", "region", "row", "rowgroup", "rowheader", "scrollbar", "search", "searchbox",
+	"separator", "slider", "spinbutton", "status", "strong", "subscript", "superscript", "switch", "tab", "table",
+	"tablist", "tabpanel", "term", "textbox", "time", "timer", "toolbar", "tooltip", "tree", "treegrid", "treeitem",
+}
+
+// ariaAttributes are the WAI-ARIA 1.2 states and properties
+var ariaAttributes = []string{
+	"aria-activedescendant", "aria-atomic", "aria-autocomplete", "aria-braillelabel", "aria-brailleroledescription",
+	"aria-busy", "aria-checked", "aria-colcount", "aria-colindex", "aria-colindextext", "aria-colspan", "aria-controls",
+	"aria-current", "aria-describedby", "aria-description", "aria-details", "aria-disabled", "aria-dropeffect",
+	"aria-errormessage", "aria-expanded", "aria-flowto", "aria-grabbed", "aria-haspopup", "aria-hidden", "aria-invalid",
+	"aria-keyshortcuts", "aria-label", "aria-labelledby", "aria-level", "aria-live", "aria-modal", "aria-multiline",
+	"aria-multiselectable", "aria-orientation", "aria-owns", "aria-placeholder", "aria-posinset", "aria-pressed",
+	"aria-readonly", "aria-relevant", "aria-required", "aria-roledescription", "aria-rowcount", "aria-rowindex",
+	"aria-rowindextext", "aria-rowspan", "aria-selected", "aria-setsize", "aria-sort", "aria-valuemax", "aria-valuemin",
+	"aria-valuenow", "aria-valuetext",
+}
+
+// ariaIDRefs are the ARIA attributes whose value is a space-separated list of element IDs
+var ariaIDRefs = []string{"aria-activedescendant", "aria-controls", "aria-describedby", "aria-details", "aria-errormessage", "aria-flowto", "aria-labelledby", "aria-owns"}
+
+// Accessibility holds the findings of the static accessibility audit
+type Accessibility struct {
+	Findings []AccessibilityFinding `json:"findings"`
+	// Counts holds the number of findings per rule
+	Counts map[string]int `json:"counts"`
+}
+
+// AccessibilityFinding is an element that fails a WCAG-oriented check
+type AccessibilityFinding struct {
+	Rule      string `json:"rule" enums:"image_alt,form_label,html_lang,button_name,link_name,generic_link_text,duplicate_id,aria_role,aria_attribute,positive_tabindex" example:"image_alt"`
+	Criterion string `json:"wcag_criterion" example:"1.1.1"`
+	Level     string `json:"wcag_level" enums:"A,AA,AAA" example:"A"`
+	Message   string `json:"message" example:"image has no alt attribute; use alt=\"\" for decorative images"`
+	Path      string `json:"path" example:"html > body > main > img:nth-of-type(2)"`
+}
+
+// AccessibilityExtractor runs static WCAG-oriented checks on the DOM
+// Only what the markup shows is checked; contrast, focus visibility and script-driven behavior need a browser
+type AccessibilityExtractor struct{}
+
+// Name returns the extractor identifier
+func (e *AccessibilityExtractor) Name() string {
+	return "accessibility"
+}
+
+// Section declares the "accessibility" section of the response
+func (e *AccessibilityExtractor) Section() models.SectionSchema {
+	return models.SectionSchema{
+		Name:        accessibilitySection,
+		Description: "Static accessibility audit: findings with their WCAG criterion and element path, and counts per rule",
+		Type:        Accessibility{},
+	}
+}
+
+// Extract indexes element IDs and labels, then checks every element
+func (e *AccessibilityExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	audit := &accessibilityAudit{
+		ids:        map[string][]*html.Node{},
+		labelFor:   map[string]bool{},
+		referenced: map[string]bool{},
+		report:     Accessibility{Findings: []AccessibilityFinding{}, Counts: map[string]int{}},
+	}
+	audit.index(doc)
+
+	for _, n := range audit.elements {
+		audit.check(n)
+	}
+	models.SetSection(result, accessibilitySection, audit.report)
+
+	return nil, nil
+}
+
+// accessibilityAudit holds the document index and the findings
+type accessibilityAudit struct {
+	elements []*html.Node
+	ids      map[string][]*html.Node
+	labelFor map[string]bool
+	// referenced holds the IDs targeted by ARIA attributes or <label for>
+	referenced map[string]bool
+	report     Accessibility
+}
+
+// index records every element in document order, the elements of each ID and the targets of labels and ARIA references
+func (a *accessibilityAudit) index(n *html.Node) {
+	if n.Type == html.ElementNode {
+		a.elements = append(a.elements, n)
+		if id, ok := getAttr(n, "id"); ok && id != "" {
+			a.ids[id] = append(a.ids[id], n)
+		}
+		if n.Data == "label" {
+			if target, ok := getAttr(n, "for"); ok && target != "" {
+				a.labelFor[target] = true
+				a.referenced[target] = true
+			}
+		}
+		for _, attr := range ariaIDRefs {
+			value, _ := getAttr(n, attr)
+			for _, id := range strings.Fields(value) {
+				a.referenced[id] = true
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		a.index(c)
+	}
+}
+
+// check runs the element checks; elements hidden from assistive technology skip the naming checks
+func (a *accessibilityAudit) check(n *html.Node) {
+	a.checkARIA(n)
+	// Labels and ARIA references resolve to the first element of a repeated ID
+	if id, _ := getAttr(n, "id"); a.referenced[id] && a.ids[id][0] != n {
+		a.finding(ruleDuplicateID, n, "id %q is used %d times and referenced by a label or ARIA attribute; only the first element is used", id, len(a.ids[id]))
+	}
+	if tabindex, ok := getAttr(n, "tabindex"); ok {
+		if value, err := strconv.Atoi(strings.TrimSpace(tabindex)); err == nil && value > 0 {
+			a.finding(rulePositiveTabindex, n, "tabindex=%d moves the element ahead of the document order; use 0 or -1", value)
+		}
+	}
+
+	if n.Data == "html" {
+		if lang, _ := getAttr(n, "lang"); strings.TrimSpace(lang) == "" {
+			a.finding(ruleHTMLLang, n, "<html> has no lang attribute; screen readers can't pick the right pronunciation")
+		}
+	}
+	if isHidden(n) {
+		return
+	}
+
+	role := a.role(n)
+	switch {
+	case n.Data == "img":
+		if _, ok := getAttr(n, "alt"); !ok && role != "presentation" && role != "none" {
+			a.finding(ruleImageAlt, n, "image has no alt attribute; use alt=\"\" for decorative images")
+		}
+	case n.Data == "input":
+		a.checkInput(n)
+	case n.Data == "select" || n.Data == "textarea":
+		if !a.labelled(n) {
+			a.finding(ruleFormLabel, n, "<%s> has no associated label", n.Data)
+		}
+	case n.Data == "button" || role == "button":
+		if a.accessibleName(n, textContent(n)) == "" {
+			a.finding(ruleButtonName, n, "button has no accessible name")
+		}
+	case (n.Data == "a" && hasAttr(n, "href")) || role == "link":
+		a.checkLink(n)
+	}
+}
+
+// checkInput checks image inputs for alternative text, button inputs for a name and the other controls for a label
+func (a *accessibilityAudit) checkInput(n *html.Node) {
+	inputType, _ := getAttr(n, "type")
+	switch strings.ToLower(strings.TrimSpace(inputType)) {
+	case "hidden", "submit", "reset":
+		// Hidden inputs aren't rendered; submit and reset buttons have a default name
+	case "image":
+		alt, _ := getAttr(n, "alt")
+		if a.accessibleName(n, alt) == "" {
+			a.finding(ruleImageAlt, n, "image button has no alt text")
+		}
+	case "button":
+		value, _ := getAttr(n, "value")
+		if a.accessibleName(n, value) == "" {
+			a.finding(ruleButtonName, n, "button has no accessible name")
+		}
+	default:
+		if !a.labelled(n) {
+			if placeholder, _ := getAttr(n, "placeholder"); strings.TrimSpace(placeholder) != "" {
+				a.finding(ruleFormLabel, n, "input is only labelled by its placeholder, which disappears on input")
+				return
+			}
+			a.finding(ruleFormLabel, n, "input has no associated label")
+		}
+	}
+}
+
+// checkLink flags links without a name and links whose name is generic text
+func (a *accessibilityAudit) checkLink(n *html.Node) {
+	name := a.accessibleName(n, textContent(n))
+	if name == "" {
+		a.finding(ruleLinkName, n, "link has no accessible name")
+		return
+	}
+	if slices.Contains(genericLinkTexts, strings.Trim(strings.ToLower(name), " .!?:…»→")) {
+		a.finding(ruleGenericLinkText, n, "link text %q doesn't describe the target", name)
+	}
+}
+
+// checkARIA flags unknown roles, unknown aria-* attributes and ID references to missing elements
+func (a *accessibilityAudit) checkARIA(n *html.Node) {
+	if role, ok := getAttr(n, "role"); ok && strings.TrimSpace(role) != "" && a.role(n) == "" {
+		a.finding(ruleARIARole, n, "role %q is not a WAI-ARIA role", strings.TrimSpace(role))
+	}
+
+	for _, attr := range n.Attr {
+		if !strings.HasPrefix(attr.Key, "aria-") {
+			continue
+		}
+		if !slices.Contains(ariaAttributes, attr.Key) {
+			a.finding(ruleARIAAttribute, n, "%s is not a WAI-ARIA attribute", attr.Key)
+			continue
+		}
+		if !slices.Contains(ariaIDRefs, attr.Key) {
+			continue
+		}
+		for _, id := range strings.Fields(attr.Val) {
+			if len(a.ids[id]) == 0 {
+				a.finding(ruleARIAAttribute, n, "%s references id %q, which doesn't exist", attr.Key, id)
+			}
+		}
+	}
+}
+
+// role returns the first valid WAI-ARIA role of an element's role attribute; later tokens are fallbacks
+func (a *accessibilityAudit) role(n *html.Node) string {
+	roles, _ := getAttr(n, "role")
+	for _, role := range strings.Fields(strings.ToLower(roles)) {
+		if slices.Contains(ariaRoles, role) || strings.HasPrefix(role, "doc-") || strings.HasPrefix(role, "graphics-") {
+			return role
+		}
+	}
+	return ""
+}
+
+// labelled reports whether a form control has a label: <label for>, a wrapping <label>, aria-labelledby, aria-label or title
+func (a *accessibilityAudit) labelled(n *html.Node) bool {
+	if id, _ := getAttr(n, "id"); id != "" && a.labelFor[id] {
+		return true
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && p.Data == "label" {
+			return true
+		}
+	}
+	return a.accessibleName(n, "") != ""
+}
+
+// accessibleName follows the name computation order: aria-labelledby, aria-label, the element's own content, then title
+func (a *accessibilityAudit) accessibleName(n *html.Node, content string) string {
+	labelledBy, _ := getAttr(n, "aria-labelledby")
+	var parts []string
+	for _, id := range strings.Fields(labelledBy) {
+		if elements := a.ids[id]; len(elements) > 0 {
+			if text := textContent(elements[0]); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	label, _ := getAttr(n, "aria-label")
+	title, _ := getAttr(n, "title")
+	return firstNonEmpty(strings.Join(parts, " "), normalizeText(label), normalizeText(content), normalizeText(title))
+}
+
+// finding records a failed check with the rule's WCAG criterion
+func (a *accessibilityAudit) finding(rule string, n *html.Node, format string, args ...any) {
+	criterion := ruleCriteria[rule]
+	a.report.Findings = append(a.report.Findings, AccessibilityFinding{
+		Rule:      rule,
+		Criterion: criterion.id,
+		Level:     criterion.level,
+		Message:   fmt.Sprintf(format, args...),
+		Path:      elementPath(n),
+	})
+	a.report.Counts[rule]++
+}
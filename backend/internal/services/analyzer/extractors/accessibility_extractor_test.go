@@ -0,0 +1,151 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+func auditAccessibility(t *testing.T, htmlContent string) Accessibility {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	var result models.AnalysisResponse
+	base, _ := url.Parse("https://example.com/")
+	if _, err := (&AccessibilityExtractor{}).Extract(context.Background(), doc, base, &result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	report, ok := models.GetSection[Accessibility](&result, accessibilitySection)
+	if !ok {
+		t.Fatalf("accessibility section missing: %+v", result.Sections)
+	}
+	return report
+}
+
+// findingPaths returns the element paths of the findings of a rule
+func findingPaths(report Accessibility, rule string) []string {
+	var paths []string
+	for _, finding := range report.Findings {
+		if finding.Rule == rule {
+			paths = append(paths, finding.Path)
+		}
+	}
+	return paths
+}
+
+func TestAccessibilityExtractor(t *testing.T) {
+	htmlContent := `<html><body>
+		<img src="/logo.png">
+		<img src="/spacer.gif" alt="">
+		<img src="/icon.png" role="presentation">
+		<div hidden><img src="/hidden.png"></div>
+
+		<form>
+			<label for="email">Email</label>
+			<input id="email" type="email">
+			<label>Name <input type="text"></label>
+			<input type="search" placeholder="Search">
+			<input type="password" aria-label="Password">
+			<input type="hidden" name="csrf">
+			<select id="country"></select>
+			<textarea aria-labelledby="missing"></textarea>
+			<input type="submit">
+			<input type="image" src="/go.png">
+			<button><img src="/x.png" alt="Close"></button>
+			<button class="icon"></button>
+			<div role="button" title="Menu"></div>
+		</form>
+
+		<a href="/pricing">Pricing</a>
+		<a href="/blog/1">Click here</a>
+		<a href="/blog/2" aria-label="Read more about the release">Read more</a>
+		<a href="/home"><img src="/home.png"></a>
+		<a name="anchor"></a>
+
+		<span id="hint">Hint</span>
+		<span id="hint">Other hint</span>
+		<input type="text" aria-label="Code" aria-describedby="hint">
+		<p id="unreferenced"></p><p id="unreferenced"></p>
+
+		<div role="buton"></div>
+		<nav role="navigation main"></nav>
+		<div aria-labeledby="hint"></div>
+		<div tabindex="3"></div>
+		<div tabindex="0"></div>
+	</body></html>`
+
+	report := auditAccessibility(t, htmlContent)
+
+	tests := []struct {
+		rule  string
+		paths []string
+	}{
+		{ruleHTMLLang, []string{"html"}},
+		{ruleImageAlt, []string{
+			"html > body > img:nth-of-type(1)",
+			"html > body > form > input:nth-of-type(6)",
+			"html > body > a:nth-of-type(4) > img",
+		}},
+		{ruleFormLabel, []string{
+			"html > body > form > input:nth-of-type(2)",
+			"html > body > form > select#country",
+			"html > body > form > textarea",
+		}},
+		{ruleButtonName, []string{"html > body > form > button:nth-of-type(2)"}},
+		{ruleLinkName, []string{"html > body > a:nth-of-type(4)"}},
+		{ruleGenericLinkText, []string{"html > body > a:nth-of-type(2)"}},
+		{ruleDuplicateID, []string{"html > body > span#hint:nth-of-type(2)"}},
+		{ruleARIARole, []string{"html > body > div:nth-of-type(2)"}},
+		{ruleARIAAttribute, []string{
+			"html > body > form > textarea",
+			"html > body > div:nth-of-type(3)",
+		}},
+		{rulePositiveTabindex, []string{"html > body > div:nth-of-type(4)"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			if got := findingPaths(report, tt.rule); !reflect.DeepEqual(got, tt.paths) {
+				t.Errorf("Expected findings at %v, got %v", tt.paths, got)
+			}
+			if report.Counts[tt.rule] != len(tt.paths) {
+				t.Errorf("Expected count %d, got %d", len(tt.paths), report.Counts[tt.rule])
+			}
+		})
+	}
+
+	for _, finding := range report.Findings {
+		if finding.Criterion == "" || finding.Level != "A" || finding.Message == "" {
+			t.Errorf("Incomplete finding: %+v", finding)
+		}
+	}
+}
+
+func TestAccessibilityExtractor_CleanPage(t *testing.T) {
+	htmlContent := `<html lang="en"><body>
+		<main>
+			<img src="/chart.png" alt="Sales by quarter">
+			<label for="q">Search</label><input id="q" type="search">
+			<button type="submit">Search</button>
+			<a href="/docs">Read the documentation</a>
+		</main>
+	</body></html>`
+
+	report := auditAccessibility(t, htmlContent)
+
+	if len(report.Findings) != 0 || len(report.Counts) != 0 {
+		t.Errorf("Expected no findings, got %+v", report.Findings)
+	}
+	if report.Findings == nil {
+		t.Error("Expected an empty, non-nil findings list")
+	}
+}
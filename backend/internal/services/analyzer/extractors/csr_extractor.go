@@ -0,0 +1,289 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+// Signal weights; a page is reported as client-side rendered once the total reaches csrThreshold
+const (
+	weightEmptyMountNode   = 3
+	weightLittleText       = 2
+	weightNoscriptWarning  = 2
+	weightScriptHeavy      = 1
+	weightHydrationMarker  = 1
+	csrThreshold           = 4
+	csrHighConfidenceScore = 6
+
+	// littleTextBytes is the visible body text below which a page with scripts looks unrendered
+	littleTextBytes = 150
+	// scriptHeavyRatio is the share of body bytes that must be inline script for a page to look script heavy
+	scriptHeavyRatio = 0.9
+)
+
+// mountNodeIDs are the element ids frameworks commonly render into
+var mountNodeIDs = map[string]bool{
+	"root":      true,
+	"app":       true,
+	"__next":    true,
+	"__nuxt":    true,
+	"___gatsby": true,
+	"svelte":    true,
+}
+
+// csrSection is the name of the section the CSR extractor writes
+const csrSection = "csr"
+
+// CSR is the client-side rendering verdict built from heuristics on the fetched DOM
+type CSR struct {
+	IsCSR      bool        `json:"is_csr" example:"true"`
+	Confidence string      `json:"confidence" enums:"low,medium,high" example:"high"`
+	Framework  string      `json:"framework,omitempty" example:"next.js"`
+	Signals    []CSRSignal `json:"signals"`
+}
+
+// CSRSignal is a single heuristic that fired during CSR detection
+type CSRSignal struct {
+	Name   string `json:"name" enums:"empty_mount_node,little_visible_text,script_heavy,hydration_marker,noscript_warning" example:"empty_mount_node"`
+	Detail string `json:"detail,omitempty" example:"#root"`
+	Weight int    `json:"weight" example:"3"`
+}
+
+// CSRExtractor detects client-side rendered pages from heuristics on the fetched DOM
+type CSRExtractor struct{}
+
+// Name returns the extractor identifier
+func (e *CSRExtractor) Name() string {
+	return "csr"
+}
+
+// Section declares the "csr" section of the response
+func (e *CSRExtractor) Section() models.SectionSchema {
+	return models.SectionSchema{
+		Name:        csrSection,
+		Description: "Client-side rendering verdict with the heuristics that fired",
+		Type:        CSR{},
+	}
+}
+
+// csrScan collects the raw observations the heuristics are based on
+type csrScan struct {
+	emptyMounts  []string
+	frameworks   []string
+	noscriptWarn bool
+	textBytes    int
+	scriptBytes  int
+	scripts      int
+}
+
+// Extract scores empty mount nodes, text vs. script volume, hydration markers and
+// <noscript> warnings, and reports a verdict with the signals that fired
+func (e *CSRExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	scan := &csrScan{}
+	if body := findElement(doc, "body"); body != nil {
+		scan.walk(body)
+	}
+	if head := findElement(doc, "head"); head != nil {
+		scan.walkScripts(head)
+	}
+
+	signals := []CSRSignal{}
+	for _, mount := range scan.emptyMounts {
+		signals = append(signals, CSRSignal{Name: "empty_mount_node", Detail: mount, Weight: weightEmptyMountNode})
+	}
+	if scan.scripts > 0 && scan.textBytes < littleTextBytes {
+		signals = append(signals, CSRSignal{Name: "little_visible_text", Weight: weightLittleText})
+	}
+	if scan.scriptBytes > 0 && float64(scan.scriptBytes) >= scriptHeavyRatio*float64(scan.scriptBytes+scan.textBytes) {
+		signals = append(signals, CSRSignal{Name: "script_heavy", Weight: weightScriptHeavy})
+	}
+	for _, framework := range scan.frameworks {
+		signals = append(signals, CSRSignal{Name: "hydration_marker", Detail: framework, Weight: weightHydrationMarker})
+	}
+	if scan.noscriptWarn {
+		signals = append(signals, CSRSignal{Name: "noscript_warning", Weight: weightNoscriptWarning})
+	}
+
+	score := 0
+	for _, signal := range signals {
+		score += signal.Weight
+	}
+
+	verdict := CSR{
+		IsCSR:      score >= csrThreshold,
+		Confidence: csrConfidence(score),
+		Signals:    signals,
+	}
+	if len(scan.frameworks) > 0 {
+		verdict.Framework = scan.frameworks[0]
+	}
+	models.SetSection(result, csrSection, verdict)
+
+	return nil, nil
+}
+
+// walk scans the body for mount nodes, visible text, scripts and noscript blocks
+func (s *csrScan) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		s.textBytes += len(strings.TrimSpace(n.Data))
+		return
+	case html.ElementNode:
+		switch n.Data {
+		case "script":
+			s.script(n)
+			return
+		case "noscript":
+			if strings.Contains(strings.ToLower(nodeText(n)), "javascript") {
+				s.noscriptWarn = true
+			}
+			return
+		case "style", "template":
+			return
+		}
+
+		id, _ := getAttr(n, "id")
+		if (mountNodeIDs[id] || n.Data == "app-root") && isEmptyNode(n) {
+			if id != "" {
+				s.emptyMounts = append(s.emptyMounts, "#"+id)
+			} else {
+				s.emptyMounts = append(s.emptyMounts, "<"+n.Data+">")
+			}
+		}
+		s.markers(n, id)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.walk(c)
+	}
+}
+
+// walkScripts only looks at scripts, used for <head> where text isn't visible
+func (s *csrScan) walkScripts(n *html.Node) {
+	if n.Type == html.ElementNode && n.Data == "script" {
+		s.script(n)
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		s.walkScripts(c)
+	}
+}
+
+// script records a script element and any framework it reveals
+func (s *csrScan) script(n *html.Node) {
+	scriptType, _ := getAttr(n, "type")
+	id, _ := getAttr(n, "id")
+	src, _ := getAttr(n, "src")
+	content := nodeText(n)
+
+	switch {
+	case id == "__NEXT_DATA__" || strings.Contains(src, "/_next/"):
+		s.framework("next.js")
+	case id == "__NUXT_DATA__" || strings.Contains(content, "window.__NUXT__") || strings.Contains(src, "/_nuxt/"):
+		s.framework("nuxt")
+	}
+
+	// JSON data islands are not executed, so they don't count as script
+	if strings.Contains(scriptType, "json") {
+		return
+	}
+	s.scripts++
+	s.scriptBytes += len(strings.TrimSpace(content))
+}
+
+// markers detects framework hydration attributes on an element
+func (s *csrScan) markers(n *html.Node, id string) {
+	switch {
+	case id == "__next":
+		s.framework("next.js")
+	case id == "__nuxt":
+		s.framework("nuxt")
+	case id == "___gatsby":
+		s.framework("gatsby")
+	}
+	for _, attr := range n.Attr {
+		switch {
+		case attr.Key == "data-reactroot":
+			s.framework("react")
+		case attr.Key == "ng-version":
+			s.framework("angular")
+		case attr.Key == "data-server-rendered" || attr.Key == "data-v-app":
+			s.framework("vue")
+		case strings.HasPrefix(attr.Key, "data-svelte"):
+			s.framework("svelte")
+		}
+	}
+}
+
+// framework records a detected framework once
+func (s *csrScan) framework(name string) {
+	for _, f := range s.frameworks {
+		if f == name {
+			return
+		}
+	}
+	s.frameworks = append(s.frameworks, name)
+}
+
+// Helper functions for CSR detection
+
+// csrConfidence maps a score to how sure the verdict is; scores near the threshold are the least certain
+func csrConfidence(score int) string {
+	switch {
+	case score >= csrHighConfidenceScore || score <= 1:
+		return "high"
+	case score == csrThreshold-1 || score == csrThreshold:
+		return "low"
+	default:
+		return "medium"
+	}
+}
+
+// findElement returns the first element with the given tag name
+func findElement(n *html.Node, tag string) *html.Node {
+	if n.Type == html.ElementNode && n.Data == tag {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, tag); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// isEmptyNode reports whether an element has no child elements and no visible text
+func isEmptyNode(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.ElementNode:
+			return false
+		case html.TextNode:
+			if strings.TrimSpace(c.Data) != "" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// nodeText concatenates the text of all descendant text nodes
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return sb.String()
+}
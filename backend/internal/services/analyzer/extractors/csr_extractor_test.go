@@ -0,0 +1,111 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+func signalNames(signals []CSRSignal) []string {
+	names := make([]string, len(signals))
+	for i, signal := range signals {
+		names[i] = signal.Name
+		if signal.Detail != "" {
+			names[i] += ":" + signal.Detail
+		}
+	}
+	return names
+}
+
+func TestCSRExtractor(t *testing.T) {
+	extractor := &CSRExtractor{}
+	testURL, _ := url.Parse("https://example.com")
+
+	tests := []struct {
+		name       string
+		html       string
+		isCSR      bool
+		confidence string
+		framework  string
+		signals    []string
+	}{
+		{
+			name:       "Static page",
+			html:       `<html><head><title>Blog</title></head><body><h1>Hello</h1><p>` + strings.Repeat("Plain server rendered text. ", 20) + `</p></body></html>`,
+			isCSR:      false,
+			confidence: "high",
+			signals:    []string{},
+		},
+		{
+			name:       "Empty React root with noscript warning",
+			html:       `<html><head><script src="/static/js/main.js"></script></head><body><noscript>You need to enable JavaScript to run this app.</noscript><div id="root"></div></body></html>`,
+			isCSR:      true,
+			confidence: "high",
+			signals:    []string{"empty_mount_node:#root", "little_visible_text", "noscript_warning"},
+		},
+		{
+			name:       "Angular shell",
+			html:       `<html><body><app-root></app-root><script src="main.js"></script><script>window.config = {"api": "https://api.example.com", "features": ["a", "b"]};</script></body></html>`,
+			isCSR:      true,
+			confidence: "high",
+			signals:    []string{"empty_mount_node:<app-root>", "little_visible_text", "script_heavy"},
+		},
+		{
+			name:       "Script-only page without mount node",
+			html:       `<html><body><p>Loading</p><script src="/bundle.js"></script></body></html>`,
+			isCSR:      false,
+			confidence: "medium",
+			signals:    []string{"little_visible_text"},
+		},
+		{
+			name:       "Server rendered Next.js page",
+			html:       `<html><body><div id="__next"><h1>Products</h1><p>` + strings.Repeat("Rendered on the server. ", 20) + `</p></div><script id="__NEXT_DATA__" type="application/json">{"props":{}}</script><script src="/_next/static/chunks/main.js"></script></body></html>`,
+			isCSR:      false,
+			confidence: "high",
+			framework:  "next.js",
+			signals:    []string{"hydration_marker:next.js"},
+		},
+		{
+			name:       "Empty Next.js mount",
+			html:       `<html><body><div id="__next"></div><script id="__NEXT_DATA__" type="application/json">{"props":{}}</script><script src="/_next/static/chunks/main.js"></script></body></html>`,
+			isCSR:      true,
+			confidence: "high",
+			framework:  "next.js",
+			signals:    []string{"empty_mount_node:#__next", "little_visible_text", "hydration_marker:next.js"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &models.AnalysisResponse{}
+			doc, err := html.Parse(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatalf("Failed to parse HTML: %v", err)
+			}
+
+			extractor.Extract(context.Background(), doc, testURL, result, tt.html)
+
+			csr, ok := models.GetSection[CSR](result, "csr")
+			if !ok {
+				t.Fatalf("csr section missing: %v", result.Sections)
+			}
+			if csr.IsCSR != tt.isCSR {
+				t.Errorf("IsCSR = %v, want %v (signals %v)", csr.IsCSR, tt.isCSR, signalNames(csr.Signals))
+			}
+			if csr.Confidence != tt.confidence {
+				t.Errorf("Confidence = %v, want %v", csr.Confidence, tt.confidence)
+			}
+			if csr.Framework != tt.framework {
+				t.Errorf("Framework = %v, want %v", csr.Framework, tt.framework)
+			}
+			if got := strings.Join(signalNames(csr.Signals), ","); got != strings.Join(tt.signals, ",") {
+				t.Errorf("Signals = %v, want %v", got, tt.signals)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package extractors
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// elementPath describes where an element sits in the DOM as a CSS-like selector path,
+// e.g. html > body > main#content > h2:nth-of-type(2)
+func elementPath(n *html.Node) string {
+	var steps []string
+	for ; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		step := n.Data
+		if id, ok := getAttr(n, "id"); ok && id != "" {
+			step += "#" + id
+		}
+		if position, count := typePosition(n); count > 1 {
+			step += fmt.Sprintf(":nth-of-type(%d)", position)
+		}
+		steps = append(steps, step)
+	}
+	slices.Reverse(steps)
+	return strings.Join(steps, " > ")
+}
+
+// typePosition returns the 1-based position of an element among its siblings of the same tag, and how many there are
+func typePosition(n *html.Node) (int, int) {
+	if n.Parent == nil {
+		return 1, 1
+	}
+	position, count := 0, 0
+	for c := n.Parent.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == n.Data {
+			count++
+			if c == n {
+				position = count
+			}
+		}
+	}
+	return position, count
+}
+
+// textContent returns the text of an element with whitespace collapsed; images contribute their alt text
+func textContent(n *html.Node) string {
+	var text strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			text.WriteString(n.Data)
+			text.WriteString(" ")
+		case n.Type == html.ElementNode && n.Data == "img":
+			if alt, ok := getAttr(n, "alt"); ok {
+				text.WriteString(alt)
+				text.WriteString(" ")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// isHidden reports whether an element or one of its ancestors is hidden with the hidden attribute or aria-hidden="true"
+func isHidden(n *html.Node) bool {
+	for ; n != nil; n = n.Parent {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		if _, ok := getAttr(n, "hidden"); ok {
+			return true
+		}
+		if ariaHidden, _ := getAttr(n, "aria-hidden"); strings.EqualFold(strings.TrimSpace(ariaHidden), "true") {
+			return true
+		}
+	}
+	return false
+}
+
+// getAttr returns the value of an element's attribute
+func getAttr(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// hasAttr reports whether an element has an attribute
+func hasAttr(n *html.Node, key string) bool {
+	_, ok := getAttr(n, key)
+	return ok
+}
@@ -1,14 +1,20 @@
 package extractors
 
 import (
+	"context"
+	"fmt"
 	"net/url"
+	"unicode/utf8"
 
 	"github.com/steve-phan/page-insight-tool/internal/models"
 
 	"golang.org/x/net/html"
 )
 
-// HeadingsExtractor extracts heading counts from HTML documents
+// maxHeadingLength is the heading length, in characters, above which a heading is flagged as too long
+const maxHeadingLength = 70
+
+// HeadingsExtractor extracts heading counts and the document outline from HTML documents
 type HeadingsExtractor struct{}
 
 // Name returns the extractor identifier
@@ -16,18 +22,86 @@ func (e *HeadingsExtractor) Name() string {
 	return "headings"
 }
 
-// Extract counts all heading elements (h1-h6) in the HTML document
-func (e *HeadingsExtractor) Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) {
+// Extract counts all heading elements (h1-h6), builds the outline in document order and checks its structure
+func (e *HeadingsExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if result.Headings == nil {
+		result.Headings = &models.Headings{}
+	}
+	headings := result.Headings
+
 	var walk func(*html.Node)
 	walk = func(n *html.Node) {
 		if n.Type == html.ElementNode && isHeadingTag(n.Data) {
-			incrementHeadingCount(n.Data, &result.Headings)
+			incrementHeadingCount(n.Data, headings)
+			headings.Outline = append(headings.Outline, models.HeadingEntry{
+				Level:  int(n.Data[1] - '0'),
+				Text:   textContent(n),
+				Path:   elementPath(n),
+				Hidden: isHidden(n),
+			})
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
 			walk(c)
 		}
 	}
 	walk(doc)
+
+	headings.Issues = checkOutline(headings.Outline)
+	return nil, nil
+}
+
+// checkOutline flags missing or multiple h1s, skipped levels, empty, overly long and hidden headings
+// Hidden headings aren't part of the outline readers perceive, so only the hidden flag applies to them
+func checkOutline(outline []models.HeadingEntry) []models.HeadingIssue {
+	var issues []models.HeadingIssue
+	h1s := 0
+	previous := 0
+	for _, heading := range outline {
+		tag := fmt.Sprintf("h%d", heading.Level)
+		if heading.Hidden {
+			issues = append(issues, models.HeadingIssue{
+				Type:    models.HeadingIssueHidden,
+				Message: fmt.Sprintf("%s is hidden from readers", tag),
+				Path:    heading.Path,
+			})
+			continue
+		}
+
+		if heading.Level == 1 {
+			h1s++
+		}
+		if previous > 0 && heading.Level > previous+1 {
+			issues = append(issues, models.HeadingIssue{
+				Type:    models.HeadingIssueSkippedLevel,
+				Message: fmt.Sprintf("h%d is followed by %s", previous, tag),
+				Path:    heading.Path,
+			})
+		}
+		previous = heading.Level
+
+		switch length := utf8.RuneCountInString(heading.Text); {
+		case length == 0:
+			issues = append(issues, models.HeadingIssue{
+				Type:    models.HeadingIssueEmpty,
+				Message: fmt.Sprintf("%s has no text", tag),
+				Path:    heading.Path,
+			})
+		case length > maxHeadingLength:
+			issues = append(issues, models.HeadingIssue{
+				Type:    models.HeadingIssueTooLong,
+				Message: fmt.Sprintf("%s is %d characters long; keep headings under %d", tag, length, maxHeadingLength),
+				Path:    heading.Path,
+			})
+		}
+	}
+
+	switch {
+	case h1s == 0:
+		issues = append(issues, models.HeadingIssue{Type: models.HeadingIssueMissingH1, Message: "page has no visible h1"})
+	case h1s > 1:
+		issues = append(issues, models.HeadingIssue{Type: models.HeadingIssueMultipleH1, Message: fmt.Sprintf("page has %d visible h1 headings", h1s)})
+	}
+	return issues
 }
 
 // isHeadingTag checks if a tag is a heading tag
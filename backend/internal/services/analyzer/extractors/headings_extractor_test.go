@@ -1,6 +1,7 @@
 package extractors
 
 import (
+	"context"
 	"net/url"
 	"strings"
 	"testing"
@@ -79,9 +80,9 @@ func TestHeadingsExtractor_Comprehensive(t *testing.T) {
 				t.Fatalf("Failed to parse HTML: %v", err)
 			}
 
-			extractor.Extract(doc, testURL, result, tt.html)
+			extractor.Extract(context.Background(), doc, testURL, result, tt.html)
 
-			if result.Headings != tt.expected {
+			if result.Headings == nil || headingCounts(result.Headings) != headingCounts(&tt.expected) {
 				t.Errorf("HeadingsExtractor failed for %s: got %+v, want %+v",
 					tt.name, result.Headings, tt.expected)
 			}
@@ -128,9 +129,9 @@ func TestHeadingsExtractor_EdgeCases(t *testing.T) {
 				t.Fatalf("Failed to parse HTML: %v", err)
 			}
 
-			extractor.Extract(doc, testURL, result, tt.html)
+			extractor.Extract(context.Background(), doc, testURL, result, tt.html)
 
-			if result.Headings != tt.expected {
+			if result.Headings == nil || headingCounts(result.Headings) != headingCounts(&tt.expected) {
 				t.Errorf("HeadingsExtractor failed for %s: got %+v, want %+v",
 					tt.name, result.Headings, tt.expected)
 			}
@@ -159,10 +160,10 @@ func TestHeadingsExtractor_Performance(t *testing.T) {
 		t.Fatalf("Failed to parse HTML: %v", err)
 	}
 
-	extractor.Extract(doc, testURL, result, htmlContent)
+	extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
 
 	expected := models.Headings{H1: 1000}
-	if result.Headings != expected {
+	if result.Headings == nil || headingCounts(result.Headings) != headingCounts(&expected) {
 		t.Errorf("HeadingsExtractor performance test failed: got %+v, want %+v",
 			result.Headings, expected)
 	}
@@ -181,13 +182,97 @@ func TestHeadingsExtractor_Idempotency(t *testing.T) {
 
 	// Run extractor multiple times - should accumulate counts
 	for i := 0; i < 3; i++ {
-		extractor.Extract(doc, testURL, result, htmlContent)
+		extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
 	}
 
 	// After 3 runs, we should have 3x the counts
 	expected := models.Headings{H1: 3, H2: 3}
-	if result.Headings != expected {
+	if result.Headings == nil || headingCounts(result.Headings) != headingCounts(&expected) {
 		t.Errorf("HeadingsExtractor idempotency test failed: got %+v, want %+v",
 			result.Headings, expected)
 	}
 }
+
+// headingCounts returns the h1-h6 counts of a headings section, for comparison
+func headingCounts(h *models.Headings) [6]int {
+	return [6]int{h.H1, h.H2, h.H3, h.H4, h.H5, h.H6}
+}
+
+func TestHeadingsExtractor_Outline(t *testing.T) {
+	extractor := &HeadingsExtractor{}
+	testURL, _ := url.Parse("https://example.com")
+	htmlContent := `<html><body>
+		<header><h1>Site <em>name</em></h1></header>
+		<main id="content">
+			<h2>  Pricing
+			</h2>
+			<h4>Skipped a level</h4>
+			<h2></h2>
+			<h2>` + strings.Repeat("Long ", 20) + `</h2>
+			<div aria-hidden="true"><h1>Decorative</h1></div>
+			<h3 hidden>Hidden</h3>
+		</main>
+		<footer><h1>Second</h1></footer>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result := &models.AnalysisResponse{}
+	if _, err := extractor.Extract(context.Background(), doc, testURL, result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	outline := result.Headings.Outline
+	if len(outline) != 8 {
+		t.Fatalf("Outline has %d headings, want 8: %+v", len(outline), outline)
+	}
+	first := models.HeadingEntry{Level: 1, Text: "Site name", Path: "html > body > header > h1"}
+	if outline[0] != first {
+		t.Errorf("outline[0] = %+v, want %+v", outline[0], first)
+	}
+	if outline[1].Text != "Pricing" || outline[1].Path != "html > body > main#content > h2:nth-of-type(1)" {
+		t.Errorf("outline[1] = %+v", outline[1])
+	}
+	if !outline[5].Hidden || !outline[6].Hidden || outline[7].Hidden {
+		t.Errorf("hidden flags = %v %v %v, want true true false", outline[5].Hidden, outline[6].Hidden, outline[7].Hidden)
+	}
+
+	var types []string
+	for _, issue := range result.Headings.Issues {
+		types = append(types, issue.Type)
+	}
+	expected := []string{
+		models.HeadingIssueSkippedLevel, // h2 -> h4
+		models.HeadingIssueEmpty,
+		models.HeadingIssueTooLong,
+		models.HeadingIssueHidden,
+		models.HeadingIssueHidden,
+		models.HeadingIssueMultipleH1, // The hidden h1 doesn't count
+	}
+	if strings.Join(types, ",") != strings.Join(expected, ",") {
+		t.Errorf("issues = %v, want %v", types, expected)
+	}
+	if issue := result.Headings.Issues[0]; issue.Message != "h2 is followed by h4" || issue.Path != "html > body > main#content > h4" {
+		t.Errorf("skipped level issue = %+v", issue)
+	}
+}
+
+func TestHeadingsExtractor_MissingH1(t *testing.T) {
+	extractor := &HeadingsExtractor{}
+	testURL, _ := url.Parse("https://example.com")
+	htmlContent := `<html><body><h2>Only a subheading</h2></body></html>`
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result := &models.AnalysisResponse{}
+	extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
+
+	issues := result.Headings.Issues
+	if len(issues) != 1 || issues[0].Type != models.HeadingIssueMissingH1 || issues[0].Path != "" {
+		t.Errorf("issues = %+v, want a single missing_h1", issues)
+	}
+}
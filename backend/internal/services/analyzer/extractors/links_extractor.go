@@ -1,51 +1,188 @@
 package extractors
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strings"
 	"sync"
-	"time"
 
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
 	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/linkcheck"
 
 	"golang.org/x/net/html"
 )
 
+// defaultChecker serves extractors created without a checker, using the default link check settings
+// It refuses internal networks like the default configuration, so links on a page can't probe them
+var defaultChecker = sync.OnceValue(func() *linkcheck.Checker {
+	return linkcheck.NewChecker(&config.Config{
+		Security: config.SecurityConfig{BlockPrivateNetworks: true},
+	})
+})
+
 // LinksExtractor extracts link information from HTML documents
-type LinksExtractor struct{}
+type LinksExtractor struct {
+	// Checker verifies external links; nil uses a checker with the default settings, internal networks blocked
+	Checker *linkcheck.Checker
+	// CheckInternal verifies internal links with the same checker; relative links of submitted HTML without a base URL are never checked
+	CheckInternal bool
+	// FirstPartyHosts are domains, subdomains included, whose links count as internal on every page
+	FirstPartyHosts []string
+}
 
 // Name returns the extractor identifier
 func (e *LinksExtractor) Name() string {
 	return "links"
 }
 
-// Extract analyzes all anchor tags and categorizes them as internal, external, or inaccessible
-func (e *LinksExtractor) Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) {
-	wg := sync.WaitGroup{}
-	mu := sync.Mutex{}
+// Extract analyzes all anchor tags and categorizes them as internal, external, inaccessible or unchecked
+// Every inaccessible checked link and unparsable href is reported as a warning
+func (e *LinksExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if result.Links == nil {
+		result.Links = &models.Links{}
+	}
+	links := result.Links
+
+	var warnings []string
+	details := []models.LinkDetail{}
 	var walk func(*html.Node)
 	walk = func(n *html.Node) {
 		if n.Type == html.ElementNode && n.Data == "a" {
-			processLinkElement(n, base, &result.Links, &wg, &mu)
+			if detail, ok := classifyLink(n, base, e.FirstPartyHosts, &warnings); ok {
+				details = append(details, detail)
+			}
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
 			walk(c)
 		}
 	}
 	walk(doc)
-	wg.Wait()
+
+	// Without a base URL relative links stay unresolved, so they can neither be checked nor crawled
+	checkInternal := e.CheckInternal && base.Host != ""
+	if checkInternal {
+		links.InternalStats = &models.LinkStats{}
+	}
+
+	var targets []string
+	for _, detail := range details {
+		if detail.Type == models.LinkTypeExternal || (detail.Type == models.LinkTypeInternal && checkInternal) {
+			targets = append(targets, detail.URL)
+		}
+	}
+
+	checker := e.Checker
+	if checker == nil {
+		checker = defaultChecker()
+	}
+	checks := checker.Check(ctx, targets)
+	if err := ctx.Err(); err != nil {
+		return warnings, err
+	}
+
+	// Every anchor is counted, but each distinct broken URL is reported once
+	reported := make(map[string]bool)
+	for i := range details {
+		detail := &details[i]
+		switch detail.Type {
+		case models.LinkTypeInvalid:
+			links.Inaccessible++
+		case models.LinkTypeInternal:
+			// Internal links keep their own statistics and never count as inaccessible
+			links.Internal++
+			if detail.Scope == models.LinkScopeSameSite {
+				links.SameSite++
+			}
+			if base.Host != "" {
+				links.InternalURLs = append(links.InternalURLs, detail.URL)
+			}
+			if checkInternal {
+				applyCheck(detail, checks[detail.URL], links.InternalStats)
+			}
+		case models.LinkTypeExternal:
+			applyCheck(detail, checks[detail.URL], &links.ExternalStats)
+			switch detail.Status {
+			case models.LinkStatusUnchecked:
+				links.Unchecked++
+			case models.LinkStatusBroken:
+				links.Inaccessible++
+			default:
+				// Links we may not fetch are counted as external without being checked
+				links.External++
+			}
+		}
+
+		if detail.Status == models.LinkStatusBroken && detail.Type != models.LinkTypeInvalid && !reported[detail.URL] {
+			reported[detail.URL] = true
+			warnings = append(warnings, fmt.Sprintf("inaccessible link %s: %s", detail.URL, checks[detail.URL].Problem()))
+		}
+	}
+
+	unchecked := links.ExternalStats.Unchecked
+	if links.InternalStats != nil {
+		unchecked += links.InternalStats.Unchecked
+	}
+	if unchecked > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d links left unchecked when the link check deadline passed", unchecked))
+	}
+	links.Details = &models.LinkDetails{Total: len(details), Items: details}
+
+	return warnings, nil
+}
+
+// applyCheck records the outcome of a link's check on its detail and in the statistics of its type
+func applyCheck(detail *models.LinkDetail, check linkcheck.Result, stats *models.LinkStats) {
+	detail.StatusCode = check.StatusCode
+	detail.ResponseTime = float64(check.Duration.Microseconds()) / 1000
+	detail.Cached = check.Cached
+	if check.FinalURL != "" && check.FinalURL != detail.URL {
+		detail.FinalURL = check.FinalURL
+		stats.Redirected++
+	}
+
+	switch {
+	case check.Unchecked:
+		stats.Unchecked++
+		detail.Status = models.LinkStatusUnchecked
+	case check.Disallowed:
+		stats.Disallowed++
+		detail.Status = models.LinkStatusDisallowed
+	case check.Reachable():
+		stats.OK++
+		detail.Status = models.LinkStatusOK
+	default:
+		stats.Broken++
+		detail.Status = models.LinkStatusBroken
+		detail.Error = check.ErrorType()
+	}
 }
 
-// processLinkElement classifies a single <a> element
-func processLinkElement(n *html.Node, base *url.URL, a *models.Links, wg *sync.WaitGroup, mu *sync.Mutex) {
-	mu.Lock()
-	defer mu.Unlock()
-	href, ok := getAttr(n, "href")
-	if !ok || href == "" {
-		a.Inaccessible++
-		return
+// classifyLink resolves a single <a> element and sets its type and scope
+// Links to the page's host or another host of its site are internal; anchors that don't navigate anywhere
+// (fragments, mailto:, javascript:, tel:) are skipped
+func classifyLink(n *html.Node, base *url.URL, firstParty []string, warnings *[]string) (models.LinkDetail, bool) {
+	href, _ := getAttr(n, "href")
+	rel, _ := getAttr(n, "rel")
+	target, _ := getAttr(n, "target")
+	detail := models.LinkDetail{
+		URL:    href,
+		Text:   textContent(n),
+		Rel:    strings.Fields(strings.ToLower(rel)),
+		Target: target,
+	}
+
+	invalid := func() (models.LinkDetail, bool) {
+		detail.Type = models.LinkTypeInvalid
+		detail.Status = models.LinkStatusBroken
+		detail.Error = string(domainerrors.ErrorTypeInvalidURL)
+		return detail, true
+	}
+
+	if href == "" {
+		return invalid()
 	}
 
 	// Skip non-navigable protocols early
@@ -53,63 +190,36 @@ func processLinkElement(n *html.Node, base *url.URL, a *models.Links, wg *sync.W
 		strings.HasPrefix(href, "javascript:") ||
 		strings.HasPrefix(href, "mailto:") ||
 		strings.HasPrefix(href, "tel:") {
-		return
+		return detail, false
 	}
 
 	parsed, err := url.Parse(href)
 	if err != nil {
-		a.Inaccessible++
-		return
-	}
-	parsed = base.ResolveReference(parsed)
-
-	if parsed.Scheme != "http" && parsed.Scheme != "https" {
-		a.Inaccessible++
-		return
+		*warnings = append(*warnings, fmt.Sprintf("unparsable link %q: %v", href, err))
+		return invalid()
 	}
 
-	if parsed.Host == "" || strings.EqualFold(parsed.Hostname(), base.Hostname()) {
-		a.Internal++
-		return
+	// Without a base URL (submitted HTML) relative links can't be resolved, but are still internal
+	if base.Host == "" && parsed.Scheme == "" && parsed.Host == "" {
+		detail.Type = models.LinkTypeInternal
+		detail.Scope = models.LinkScopeSameHost
+		detail.Status = models.LinkStatusNotChecked
+		return detail, true
 	}
+	parsed = base.ResolveReference(parsed)
+	detail.URL = parsed.String()
 
-	// External link: check accessibility concurrently
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-
-		if !isReachable(parsed.String()) {
-			mu.Lock()
-			fmt.Println("Inaccessible link found:", parsed.String())
-			a.Inaccessible++
-			mu.Unlock()
-		} else {
-			mu.Lock()
-			a.External++
-			mu.Unlock()
-		}
-	}()
-}
-
-func getAttr(n *html.Node, key string) (string, bool) {
-	for _, attr := range n.Attr {
-		if attr.Key == key {
-			return attr.Val, true
-		}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return invalid()
 	}
-	return "", false
-}
 
-func isReachable(url string) bool {
-	client := http.Client{
-		Timeout: 3 * time.Second,
-	}
-	res, err := client.Get(url)
-	if err != nil || res.StatusCode >= 400 {
-		return false
+	detail.Scope = linkScope(parsed.Hostname(), base.Hostname(), firstParty)
+	if detail.Scope == models.LinkScopeExternal {
+		detail.Type = models.LinkTypeExternal
+		return detail, true
 	}
 
-	defer res.Body.Close()
-	return true
+	detail.Type = models.LinkTypeInternal
+	detail.Status = models.LinkStatusNotChecked
+	return detail, true
 }
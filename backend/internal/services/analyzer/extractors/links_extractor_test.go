@@ -0,0 +1,213 @@
+package extractors
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/linkcheck"
+
+	"golang.org/x/net/html"
+)
+
+// newLoopbackChecker returns a checker that may reach the loopback test servers
+func newLoopbackChecker() *linkcheck.Checker {
+	return linkcheck.NewChecker(&config.Config{})
+}
+
+func TestLinksExtractor(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	extractor := &LinksExtractor{Checker: newLoopbackChecker()}
+	testURL, _ := url.Parse("https://example.com")
+	htmlContent := `<html><body>
+		<a href="/about">About</a>
+		<a href="https://example.com/contact">Contact</a>
+		<a href="https://blog.example.com/post">Blog</a>
+		<a href="` + ts.URL + `/ok">External</a>
+		<a href="` + ts.URL + `/gone">Gone</a>
+		<a href="mailto:hi@example.com">Mail</a>
+		<a>No href</a>
+	</body></html>`
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result := &models.AnalysisResponse{}
+	warnings, err := extractor.Extract(context.Background(), doc, testURL, result, htmlContent)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	// The subdomain belongs to the same site, so it is internal and left unchecked
+	expected := models.Links{Internal: 3, SameSite: 1, External: 1, Inaccessible: 2}
+	if result.Links == nil || result.Links.Internal != expected.Internal || result.Links.SameSite != expected.SameSite ||
+		result.Links.External != expected.External || result.Links.Inaccessible != expected.Inaccessible {
+		t.Errorf("Links = %+v, want %+v", result.Links, expected)
+	}
+
+	// Only the unreachable link is worth a warning; a missing href is just counted
+	if len(warnings) != 1 || !strings.Contains(warnings[0], ts.URL+"/gone") || !strings.Contains(warnings[0], "HTTP 404") {
+		t.Errorf("warnings = %v, want one warning for the 404 link", warnings)
+	}
+}
+
+func TestLinksExtractor_Details(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	extractor := &LinksExtractor{Checker: newLoopbackChecker()}
+	testURL, _ := url.Parse("https://example.com/blog/")
+	htmlContent := `<html><body>
+		<a href="post">  Read
+			<b>more</b> </a>
+		<a href="` + ts.URL + `/ok" rel="NoFollow  noopener" target="_blank"><img src="logo.png" alt="Partner"></a>
+		<a href="` + ts.URL + `/gone" rel="ugc">Gone</a>
+		<a href="#top">Top</a>
+		<a href="ftp://example.com/file">FTP</a>
+	</body></html>`
+
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result := &models.AnalysisResponse{}
+	if _, err := extractor.Extract(context.Background(), doc, testURL, result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	details := result.Links.Details
+	if details == nil || details.Total != 4 || len(details.Items) != 4 {
+		t.Fatalf("Details = %+v, want 4 links (the fragment link is skipped)", details)
+	}
+
+	internal := details.Items[0]
+	if internal.URL != "https://example.com/blog/post" || internal.Text != "Read more" ||
+		internal.Type != models.LinkTypeInternal || internal.Status != models.LinkStatusNotChecked {
+		t.Errorf("internal link = %+v", internal)
+	}
+
+	ok := details.Items[1]
+	if ok.Text != "Partner" || ok.Target != "_blank" || strings.Join(ok.Rel, ",") != "nofollow,noopener" ||
+		ok.Type != models.LinkTypeExternal || ok.Status != models.LinkStatusOK || ok.StatusCode != http.StatusOK || ok.ResponseTime <= 0 {
+		t.Errorf("reachable link = %+v", ok)
+	}
+
+	gone := details.Items[2]
+	if gone.Status != models.LinkStatusBroken || gone.StatusCode != http.StatusNotFound || gone.Error != "HTTP_NOT_FOUND" {
+		t.Errorf("broken link = %+v", gone)
+	}
+
+	invalid := details.Items[3]
+	if invalid.Type != models.LinkTypeInvalid || invalid.Status != models.LinkStatusBroken || invalid.Error != "INVALID_URL" {
+		t.Errorf("invalid link = %+v", invalid)
+	}
+}
+
+func TestLinksExtractor_CheckInternal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/gone":
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	base, _ := url.Parse(ts.URL + "/")
+	htmlContent := `<html><body>
+		<a href="/new">New</a>
+		<a href="/old">Old</a>
+		<a href="/gone">Gone</a>
+		<a href="/gone">Gone again</a>
+	</body></html>`
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	// Internal links are only checked when asked to
+	result := &models.AnalysisResponse{}
+	if _, err := (&LinksExtractor{}).Extract(context.Background(), doc, base, result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.Links.InternalStats != nil || result.Links.Details.Items[0].Status != models.LinkStatusNotChecked {
+		t.Errorf("Links = %+v, want internal links left unchecked", result.Links)
+	}
+
+	result = &models.AnalysisResponse{}
+	warnings, err := (&LinksExtractor{Checker: newLoopbackChecker(), CheckInternal: true}).Extract(context.Background(), doc, base, result, htmlContent)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	links := result.Links
+	if links.Internal != 4 || links.Inaccessible != 0 {
+		t.Errorf("Links = %+v, want 4 internal links and no inaccessible ones", links)
+	}
+	expected := models.LinkStats{OK: 2, Broken: 2, Redirected: 1}
+	if links.InternalStats == nil || *links.InternalStats != expected {
+		t.Errorf("InternalStats = %+v, want %+v", links.InternalStats, expected)
+	}
+	if links.ExternalStats != (models.LinkStats{}) {
+		t.Errorf("ExternalStats = %+v, want no external checks", links.ExternalStats)
+	}
+
+	old := links.Details.Items[1]
+	if old.Status != models.LinkStatusOK || old.FinalURL != ts.URL+"/new" {
+		t.Errorf("redirected link = %+v, want ok with its redirect target", old)
+	}
+	if gone := links.Details.Items[2]; gone.Status != models.LinkStatusBroken || gone.StatusCode != http.StatusNotFound {
+		t.Errorf("broken link = %+v", gone)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], ts.URL+"/gone") {
+		t.Errorf("warnings = %v, want one warning for the broken internal link", warnings)
+	}
+}
+
+func TestLinksExtractor_DefaultCheckerBlocksInternalNetworks(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer ts.Close()
+
+	base, _ := url.Parse("https://example.com/")
+	htmlContent := `<html><body><a href="` + ts.URL + `/admin">Admin</a></body></html>`
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	result := &models.AnalysisResponse{}
+	if _, err := (&LinksExtractor{}).Extract(context.Background(), doc, base, result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	if detail := result.Links.Details.Items[0]; detail.Status != models.LinkStatusBroken || detail.Error != "DESTINATION_BLOCKED" {
+		t.Errorf("loopback link = %+v, want it blocked", detail)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no request to reach the loopback server, got %d", requests)
+	}
+}
@@ -1,6 +1,7 @@
 package extractors
 
 import (
+	"context"
 	"net/url"
 
 	"github.com/steve-phan/page-insight-tool/internal/models"
@@ -17,27 +18,31 @@ func (e *LoginFormExtractor) Name() string {
 }
 
 // Extract searches for forms containing password input fields to detect login forms
-func (e *LoginFormExtractor) Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) {
-	if result.HasLoginForm {
-		return // Already found
+func (e *LoginFormExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if result.HasLoginForm != nil && *result.HasLoginForm {
+		return nil, nil // Already found
 	}
 
+	found := false
 	var walk func(*html.Node)
 	walk = func(n *html.Node) {
 		if n.Type == html.ElementNode && n.Data == "form" {
 			if formHasPasswordField(n) {
-				result.HasLoginForm = true
+				found = true
 				return
 			}
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
 			walk(c)
-			if result.HasLoginForm {
+			if found {
 				return
 			}
 		}
 	}
 	walk(doc)
+	result.HasLoginForm = &found
+
+	return nil, nil
 }
 
 // formHasPasswordField checks if a form contains a password field
@@ -0,0 +1,288 @@
+package extractors
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+// Recommended lengths, in characters, of the texts shown in search results and share previews
+const (
+	minDescriptionLength       = 50
+	maxDescriptionLength       = 160
+	maxOpenGraphTitleLength    = 95
+	maxTwitterTitleLength      = 70
+	maxSocialDescriptionLength = 200
+)
+
+// metaSection is the name of the section the meta extractor writes
+const metaSection = "meta"
+
+// requiredOpenGraph are the properties every OpenGraph object must have
+var requiredOpenGraph = []string{"og:title", "og:type", "og:image", "og:url"}
+
+// twitterCards are the valid twitter:card values
+var twitterCards = []string{"summary", "summary_large_image", "app", "player"}
+
+// Meta holds the page's meta tags and the social share preview they produce
+type Meta struct {
+	// Title is the <title> found by the title extractor, or og:title when the page has none
+	Title string `json:"title,omitempty" example:"Pricing"`
+	// TitleFallback is set when Title came from og:title
+	TitleFallback bool   `json:"title_fallback,omitempty" example:"false"`
+	Description   string `json:"description,omitempty" example:"Compare plans and prices."`
+	Robots        string `json:"robots,omitempty" example:"index, follow"`
+	Viewport      string `json:"viewport,omitempty" example:"width=device-width, initial-scale=1"`
+	// Canonical is the resolved href of <link rel="canonical">
+	Canonical  string `json:"canonical,omitempty" example:"https://example.com/pricing"`
+	ThemeColor string `json:"theme_color,omitempty" example:"#0f172a"`
+	// OpenGraph and Twitter hold the og:* and twitter:* tags by property; the first of repeated tags wins
+	OpenGraph map[string]string `json:"open_graph,omitempty"`
+	Twitter   map[string]string `json:"twitter,omitempty"`
+	Preview   SocialPreview     `json:"preview"`
+	Issues    []MetaIssue       `json:"issues,omitempty"`
+}
+
+// SocialPreview is what a link share would display, following the fallbacks social networks apply
+type SocialPreview struct {
+	Title string `json:"title,omitempty" example:"Pricing"`
+	// TitleSource is the tag the title came from
+	TitleSource string `json:"title_source,omitempty" enums:"og:title,twitter:title,title" example:"og:title"`
+	Description string `json:"description,omitempty" example:"Compare plans and prices."`
+	Image       string `json:"image,omitempty" example:"https://example.com/og.png"`
+	URL         string `json:"url,omitempty" example:"https://example.com/pricing"`
+	SiteName    string `json:"site_name,omitempty" example:"Example"`
+	Card        string `json:"card" enums:"summary,summary_large_image,app,player" example:"summary_large_image"`
+}
+
+// MetaIssue is a missing, invalid or badly sized meta tag
+type MetaIssue struct {
+	Field   string `json:"field" example:"description"`
+	Message string `json:"message" example:"description is 212 characters long; keep it between 50 and 160"`
+}
+
+// MetaExtractor collects meta tags, OpenGraph and Twitter Card tags and builds the social share preview
+type MetaExtractor struct{}
+
+// Name returns the extractor identifier
+func (e *MetaExtractor) Name() string {
+	return "meta"
+}
+
+// DependsOn waits for the title extractor, so a missing <title> can fall back to og:title in the meta section
+func (e *MetaExtractor) DependsOn() []string {
+	return []string{"title"}
+}
+
+// Section declares the "meta" section of the response
+func (e *MetaExtractor) Section() models.SectionSchema {
+	return models.SectionSchema{
+		Name:        metaSection,
+		Description: "Meta description, robots, viewport, canonical, theme color, OpenGraph and Twitter Card tags with the resulting share preview",
+		Type:        Meta{},
+	}
+}
+
+// Extract reads the meta and link tags of the document, checks them and builds the share preview
+// When the page has no title, og:title is used as the meta title; page_title is left to the title extractor
+func (e *MetaExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	meta := Meta{
+		OpenGraph: map[string]string{},
+		Twitter:   map[string]string{},
+	}
+	var canonicals []string
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "meta":
+				meta.read(n)
+			case "link":
+				if rel, _ := getAttr(n, "rel"); slices.Contains(strings.Fields(strings.ToLower(rel)), "canonical") {
+					href, _ := getAttr(n, "href")
+					canonicals = append(canonicals, resolveURL(base, href))
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	if len(canonicals) > 0 {
+		meta.Canonical = canonicals[0]
+	}
+	if len(canonicals) > 1 {
+		meta.issue("canonical", "page declares %d canonical URLs; search engines may ignore all of them", len(canonicals))
+	}
+	resolve := func(tags map[string]string, key string) {
+		if value, ok := tags[key]; ok {
+			tags[key] = resolveURL(base, value)
+		}
+	}
+	resolve(meta.OpenGraph, "og:image")
+	resolve(meta.OpenGraph, "og:url")
+	resolve(meta.Twitter, "twitter:image")
+
+	var warnings []string
+	if result.PageTitle != nil {
+		meta.Title = *result.PageTitle
+	}
+	if ogTitle := meta.OpenGraph["og:title"]; meta.Title == "" && ogTitle != "" {
+		meta.Title = ogTitle
+		meta.TitleFallback = true
+		warnings = append(warnings, "page has no <title>; using og:title as the meta title")
+	}
+
+	meta.check()
+	meta.Preview = meta.preview(meta.Title, base)
+	if len(meta.OpenGraph) == 0 {
+		meta.OpenGraph = nil
+	}
+	if len(meta.Twitter) == 0 {
+		meta.Twitter = nil
+	}
+	models.SetSection(result, metaSection, meta)
+
+	return warnings, nil
+}
+
+// read records a single <meta> element; og:* and twitter:* tags are accepted in either name or property
+func (m *Meta) read(n *html.Node) {
+	key, ok := getAttr(n, "property")
+	if !ok {
+		key, _ = getAttr(n, "name")
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	content, _ := getAttr(n, "content")
+	content = normalizeText(content)
+
+	first := func(field *string) {
+		if *field == "" {
+			*field = content
+		}
+	}
+	switch {
+	case key == "description":
+		first(&m.Description)
+	case key == "robots":
+		first(&m.Robots)
+	case key == "viewport":
+		first(&m.Viewport)
+	case key == "theme-color":
+		first(&m.ThemeColor)
+	case strings.HasPrefix(key, "og:"):
+		if _, seen := m.OpenGraph[key]; !seen {
+			m.OpenGraph[key] = content
+		}
+	case strings.HasPrefix(key, "twitter:"):
+		if _, seen := m.Twitter[key]; !seen {
+			m.Twitter[key] = content
+		}
+	}
+}
+
+// check flags missing required tags and texts outside the recommended lengths
+func (m *Meta) check() {
+	switch length := utf8.RuneCountInString(m.Description); {
+	case m.Description == "":
+		m.issue("description", "no meta description; search engines will pick a snippet from the page")
+	case length < minDescriptionLength || length > maxDescriptionLength:
+		m.issue("description", "description is %d characters long; keep it between %d and %d", length, minDescriptionLength, maxDescriptionLength)
+	}
+	if m.Viewport == "" {
+		m.issue("viewport", "no viewport meta tag; mobile browsers will render the desktop layout")
+	}
+	for _, directive := range strings.Split(strings.ToLower(m.Robots), ",") {
+		if directive = strings.TrimSpace(directive); directive == "noindex" || directive == "none" {
+			m.issue("robots", "robots meta tag %q keeps the page out of search results", m.Robots)
+			break
+		}
+	}
+
+	if len(m.OpenGraph) == 0 {
+		m.issue("open_graph", "no OpenGraph tags; share previews will be guessed from the page")
+	} else {
+		for _, property := range requiredOpenGraph {
+			if m.OpenGraph[property] == "" {
+				m.issue(property, "required OpenGraph property %s is missing", property)
+			}
+		}
+	}
+	m.checkLength("og:title", m.OpenGraph["og:title"], maxOpenGraphTitleLength)
+	m.checkLength("og:description", m.OpenGraph["og:description"], maxSocialDescriptionLength)
+
+	if len(m.Twitter) > 0 {
+		card := m.Twitter["twitter:card"]
+		switch {
+		case card == "":
+			m.issue("twitter:card", "Twitter tags without twitter:card are ignored")
+		case !slices.Contains(twitterCards, card):
+			m.issue("twitter:card", "unknown twitter:card %q, expected one of %s", card, strings.Join(twitterCards, ", "))
+		}
+	}
+	m.checkLength("twitter:title", m.Twitter["twitter:title"], maxTwitterTitleLength)
+	m.checkLength("twitter:description", m.Twitter["twitter:description"], maxSocialDescriptionLength)
+}
+
+// checkLength flags a text longer than previews show
+func (m *Meta) checkLength(field, text string, max int) {
+	if length := utf8.RuneCountInString(text); length > max {
+		m.issue(field, "%s is %d characters long; previews cut it after about %d", field, length, max)
+	}
+}
+
+// issue records a problem with a field
+func (m *Meta) issue(field, format string, args ...any) {
+	m.Issues = append(m.Issues, MetaIssue{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// preview applies the fallbacks social networks use: OpenGraph first, then Twitter, then the plain page tags
+func (m *Meta) preview(pageTitle string, base *url.URL) SocialPreview {
+	preview := SocialPreview{
+		Description: firstNonEmpty(m.OpenGraph["og:description"], m.Twitter["twitter:description"], m.Description),
+		Image:       firstNonEmpty(m.OpenGraph["og:image"], m.Twitter["twitter:image"]),
+		URL:         firstNonEmpty(m.OpenGraph["og:url"], m.Canonical, base.String()),
+		SiteName:    firstNonEmpty(m.OpenGraph["og:site_name"], base.Hostname()),
+		Card:        firstNonEmpty(m.Twitter["twitter:card"], "summary"),
+	}
+
+	switch {
+	case m.OpenGraph["og:title"] != "":
+		preview.Title, preview.TitleSource = m.OpenGraph["og:title"], "og:title"
+	case m.Twitter["twitter:title"] != "":
+		preview.Title, preview.TitleSource = m.Twitter["twitter:title"], "twitter:title"
+	case pageTitle != "":
+		preview.Title, preview.TitleSource = pageTitle, "title"
+	}
+	return preview
+}
+
+// resolveURL resolves an href against the base URL; unparsable hrefs are returned unchanged
+func resolveURL(base *url.URL, href string) string {
+	href = strings.TrimSpace(href)
+	parsed, err := url.Parse(href)
+	if err != nil || href == "" {
+		return href
+	}
+	return base.ResolveReference(parsed).String()
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
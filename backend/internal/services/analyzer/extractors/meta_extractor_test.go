@@ -0,0 +1,139 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+func extractMeta(t *testing.T, htmlContent string, result *models.AnalysisResponse) (Meta, []string) {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	base, _ := url.Parse("https://example.com/blog/post")
+	warnings, err := (&MetaExtractor{}).Extract(context.Background(), doc, base, result, htmlContent)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	meta, ok := models.GetSection[Meta](result, metaSection)
+	if !ok {
+		t.Fatalf("meta section missing: %+v", result.Sections)
+	}
+	return meta, warnings
+}
+
+func fieldsWithIssues(meta Meta) []string {
+	var fields []string
+	for _, issue := range meta.Issues {
+		fields = append(fields, issue.Field)
+	}
+	return fields
+}
+
+func TestMetaExtractor(t *testing.T) {
+	htmlContent := `<html><head>
+		<title>Post</title>
+		<meta name="description" content="  A complete walkthrough of the new release,
+			with examples for every feature. ">
+		<meta name="viewport" content="width=device-width, initial-scale=1">
+		<meta name="robots" content="index, follow">
+		<meta name="theme-color" content="#0f172a">
+		<meta name="theme-color" content="#ffffff" media="(prefers-color-scheme: light)">
+		<link rel="canonical" href="/blog/post">
+		<meta property="og:title" content="The new release">
+		<meta property="og:type" content="article">
+		<meta property="og:image" content="/img/og.png">
+		<meta property="og:url" content="https://example.com/blog/post">
+		<meta property="og:site_name" content="Example Blog">
+		<meta name="twitter:card" content="summary_large_image">
+		<meta name="twitter:title" content="` + strings.Repeat("x", 80) + `">
+	</head><body></body></html>`
+
+	title := "Post"
+	meta, warnings := extractMeta(t, htmlContent, &models.AnalysisResponse{PageTitle: &title})
+
+	if meta.Description != "A complete walkthrough of the new release, with examples for every feature." {
+		t.Errorf("Description = %q", meta.Description)
+	}
+	if meta.ThemeColor != "#0f172a" || meta.Robots != "index, follow" || meta.Canonical != "https://example.com/blog/post" {
+		t.Errorf("Meta = %+v", meta)
+	}
+	if meta.OpenGraph["og:image"] != "https://example.com/img/og.png" {
+		t.Errorf("og:image = %q, want it resolved", meta.OpenGraph["og:image"])
+	}
+
+	expected := SocialPreview{
+		Title:       "The new release",
+		TitleSource: "og:title",
+		Description: meta.Description,
+		Image:       "https://example.com/img/og.png",
+		URL:         "https://example.com/blog/post",
+		SiteName:    "Example Blog",
+		Card:        "summary_large_image",
+	}
+	if meta.Preview != expected {
+		t.Errorf("Preview = %+v, want %+v", meta.Preview, expected)
+	}
+
+	if fields := fieldsWithIssues(meta); strings.Join(fields, ",") != "twitter:title" {
+		t.Errorf("issues = %+v, want only the long twitter:title", meta.Issues)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("warnings = %v, want none", warnings)
+	}
+}
+
+func TestMetaExtractor_Issues(t *testing.T) {
+	htmlContent := `<html><head>
+		<meta name="description" content="Too short">
+		<meta name="robots" content="noindex, nofollow">
+		<link rel="canonical" href="https://example.com/a">
+		<link rel="canonical" href="https://example.com/b">
+		<meta property="og:title" content="Shared title">
+		<meta name="twitter:title" content="No card">
+	</head><body></body></html>`
+
+	empty := ""
+	result := &models.AnalysisResponse{PageTitle: &empty}
+	meta, warnings := extractMeta(t, htmlContent, result)
+
+	expected := []string{"canonical", "description", "viewport", "robots", "og:type", "og:image", "og:url", "twitter:card"}
+	if fields := fieldsWithIssues(meta); strings.Join(fields, ",") != strings.Join(expected, ",") {
+		t.Errorf("issue fields = %v, want %v", fields, expected)
+	}
+
+	// A page without a <title> falls back to og:title in the meta section, never in page_title
+	if meta.Title != "Shared title" || !meta.TitleFallback || len(warnings) != 1 {
+		t.Errorf("Title = %q, TitleFallback = %v, warnings = %v, want the og:title fallback", meta.Title, meta.TitleFallback, warnings)
+	}
+	if *result.PageTitle != "" {
+		t.Errorf("PageTitle = %q, want it left to the title extractor", *result.PageTitle)
+	}
+	if meta.Preview.URL != "https://example.com/a" || meta.Preview.SiteName != "example.com" || meta.Preview.Card != "summary" {
+		t.Errorf("Preview = %+v, want canonical URL, host and summary card fallbacks", meta.Preview)
+	}
+}
+
+func TestMetaExtractor_NoTags(t *testing.T) {
+	title := "Plain page"
+	meta, _ := extractMeta(t, `<html><head><title>Plain page</title></head></html>`, &models.AnalysisResponse{PageTitle: &title})
+
+	if meta.OpenGraph != nil || meta.Twitter != nil {
+		t.Errorf("OpenGraph = %v, Twitter = %v, want none", meta.OpenGraph, meta.Twitter)
+	}
+	if meta.Preview.Title != "Plain page" || meta.Preview.TitleSource != "title" {
+		t.Errorf("Preview = %+v, want the page title", meta.Preview)
+	}
+	if fields := fieldsWithIssues(meta); strings.Join(fields, ",") != "description,viewport,open_graph" {
+		t.Errorf("issue fields = %v", fields)
+	}
+}
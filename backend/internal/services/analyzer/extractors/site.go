@@ -0,0 +1,56 @@
+package extractors
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// linkScope tells whether a link's host is the page's host, another host of the same site, or external
+// Hosts listed as first-party count as the same site on every page
+func linkScope(host, pageHost string, firstParty []string) string {
+	host = normalizeHost(host)
+	pageHost = normalizeHost(pageHost)
+
+	switch {
+	case host == pageHost:
+		return models.LinkScopeSameHost
+	case pageHost != "" && registrableDomain(host) == registrableDomain(pageHost):
+		return models.LinkScopeSameSite
+	case isFirstParty(host, firstParty):
+		return models.LinkScopeSameSite
+	}
+	return models.LinkScopeExternal
+}
+
+// registrableDomain returns the eTLD+1 of a host per the public suffix list, e.g. example.co.uk for www.example.co.uk
+// IP addresses, single-label hosts and public suffixes themselves are their own site
+func registrableDomain(host string) string {
+	if _, err := netip.ParseAddr(host); err == nil {
+		return host
+	}
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return domain
+}
+
+// isFirstParty reports whether the host is, or is a subdomain of, one of the first-party entries
+func isFirstParty(host string, firstParty []string) bool {
+	for _, entry := range firstParty {
+		entry = normalizeHost(strings.TrimPrefix(strings.TrimSpace(entry), "*."))
+		if entry != "" && (host == entry || strings.HasSuffix(host, "."+entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHost lowercases a hostname and drops the trailing dot of a fully qualified name
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
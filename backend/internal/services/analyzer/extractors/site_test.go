@@ -0,0 +1,40 @@
+package extractors
+
+import (
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+)
+
+func TestLinkScope(t *testing.T) {
+	firstParty := []string{"cdn.example.net", "*.sister-brand.com"}
+
+	tests := []struct {
+		host     string
+		pageHost string
+		want     string
+	}{
+		{"www.example.com", "www.example.com", models.LinkScopeSameHost},
+		{"WWW.Example.com.", "www.example.com", models.LinkScopeSameHost},
+		{"example.com", "www.example.com", models.LinkScopeSameSite},
+		{"blog.example.com", "www.example.com", models.LinkScopeSameSite},
+		{"shop.example.co.uk", "www.example.co.uk", models.LinkScopeSameSite},
+		{"other.co.uk", "www.example.co.uk", models.LinkScopeExternal},
+		// Private suffixes like github.io separate their tenants
+		{"bob.github.io", "alice.github.io", models.LinkScopeExternal},
+		{"cdn.example.net", "www.example.com", models.LinkScopeSameSite},
+		{"img.cdn.example.net", "www.example.com", models.LinkScopeSameSite},
+		{"example.net", "www.example.com", models.LinkScopeExternal},
+		{"sister-brand.com", "www.example.com", models.LinkScopeSameSite},
+		{"notsister-brand.com", "www.example.com", models.LinkScopeExternal},
+		{"127.0.0.1", "127.0.0.2", models.LinkScopeExternal},
+		{"localhost", "localhost", models.LinkScopeSameHost},
+		{"example.com", "", models.LinkScopeExternal},
+	}
+
+	for _, tt := range tests {
+		if got := linkScope(tt.host, tt.pageHost, firstParty); got != tt.want {
+			t.Errorf("linkScope(%q, %q) = %q, want %q", tt.host, tt.pageHost, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package extractors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+// structuredDataSection is the name of the section the structured data extractor writes
+const structuredDataSection = "structured_data"
+
+// Structured data formats
+const (
+	formatJSONLD    = "json-ld"
+	formatMicrodata = "microdata"
+	formatRDFa      = "rdfa"
+)
+
+// schemaOrgPrefixes are stripped from types and property names so every format reports "Product", not a URL
+var schemaOrgPrefixes = []string{"https://schema.org/", "http://schema.org/", "schema:"}
+
+// requiredProperties lists the properties each schema.org type must have; "a|b" accepts either
+var requiredProperties = map[string][]string{
+	"Product":        {"name", "offers|review|aggregateRating"},
+	"Article":        {"headline", "image", "author", "datePublished"},
+	"NewsArticle":    {"headline", "image", "author", "datePublished"},
+	"BlogPosting":    {"headline", "image", "author", "datePublished"},
+	"Organization":   {"name", "url"},
+	"BreadcrumbList": {"itemListElement"},
+	"ListItem":       {"position", "name|item"},
+}
+
+// StructuredData is the schema.org graph found in JSON-LD, Microdata and RDFa Lite markup
+// Nested entities are listed on their own and referenced by ID, so every entity appears once
+type StructuredData struct {
+	Entities []Entity              `json:"entities"`
+	Errors   []StructuredDataError `json:"errors,omitempty"`
+}
+
+// Entity is one node of the structured data graph
+type Entity struct {
+	// ID is the @id, itemid or resource of the entity, or a blank node ID like _:b0
+	ID     string   `json:"id" example:"_:b0"`
+	Types  []string `json:"types" example:"Product"`
+	Format string   `json:"format" enums:"json-ld,microdata,rdfa" example:"json-ld"`
+	// Path locates the <script> or element the entity was declared in
+	Path       string                     `json:"path" example:"html > head > script:nth-of-type(2)"`
+	Properties map[string][]PropertyValue `json:"properties"`
+	// MissingProperties lists required properties of the entity's type it doesn't have
+	MissingProperties []string `json:"missing_properties,omitempty" example:"offers|review|aggregateRating"`
+}
+
+// PropertyValue is either a literal or a reference to another entity of the graph
+type PropertyValue struct {
+	Value string `json:"value,omitempty" example:"Running shoe"`
+	Ref   string `json:"ref,omitempty" example:"_:b1"`
+}
+
+// StructuredDataError is markup that couldn't be read, e.g. a JSON-LD block with a syntax error
+type StructuredDataError struct {
+	Format  string `json:"format" enums:"json-ld,microdata,rdfa" example:"json-ld"`
+	Path    string `json:"path" example:"html > head > script"`
+	Message string `json:"message" example:"invalid JSON at line 4: invalid character '}' looking for beginning of object key string"`
+}
+
+// markupSyntax holds the attribute names of an attribute-based format
+type markupSyntax struct {
+	format   string
+	scope    string // Attribute that starts a new entity
+	types    string
+	property string
+	id       string
+}
+
+var (
+	microdataSyntax = markupSyntax{format: formatMicrodata, scope: "itemscope", types: "itemtype", property: "itemprop", id: "itemid"}
+	rdfaSyntax      = markupSyntax{format: formatRDFa, scope: "typeof", types: "typeof", property: "property", id: "resource"}
+)
+
+// StructuredDataExtractor extracts schema.org entities from JSON-LD, Microdata and RDFa Lite
+type StructuredDataExtractor struct{}
+
+// Name returns the extractor identifier
+func (e *StructuredDataExtractor) Name() string {
+	return "structured_data"
+}
+
+// Section declares the "structured_data" section of the response
+func (e *StructuredDataExtractor) Section() models.SectionSchema {
+	return models.SectionSchema{
+		Name:        structuredDataSection,
+		Description: "schema.org entities from JSON-LD, Microdata and RDFa Lite as a graph, with syntax errors and missing required properties",
+		Type:        StructuredData{},
+	}
+}
+
+// Extract parses every JSON-LD block and Microdata and RDFa Lite item, then checks required properties
+func (e *StructuredDataExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	graph := &structuredGraph{base: base, data: StructuredData{Entities: []Entity{}}}
+
+	graph.walkJSONLD(doc)
+	graph.walkMarkup(doc, microdataSyntax, nil)
+	graph.walkMarkup(doc, rdfaSyntax, nil)
+
+	for i := range graph.data.Entities {
+		graph.data.Entities[i].MissingProperties = missingProperties(graph.data.Entities[i])
+	}
+	models.SetSection(result, structuredDataSection, graph.data)
+
+	return nil, nil
+}
+
+// structuredGraph collects the entities of all formats
+type structuredGraph struct {
+	base       *url.URL
+	data       StructuredData
+	blankNodes int
+}
+
+// newEntity adds an entity to the graph and returns its index
+func (g *structuredGraph) newEntity(format, path, id string, types []string) int {
+	if id == "" {
+		id = fmt.Sprintf("_:b%d", g.blankNodes)
+		g.blankNodes++
+	}
+	normalized := make([]string, 0, len(types))
+	for _, t := range types {
+		normalized = append(normalized, stripSchemaOrg(t))
+	}
+	g.data.Entities = append(g.data.Entities, Entity{
+		ID:         id,
+		Types:      normalized,
+		Format:     format,
+		Path:       path,
+		Properties: map[string][]PropertyValue{},
+	})
+	return len(g.data.Entities) - 1
+}
+
+// addProperty appends a value to a property of the entity at the given index
+func (g *structuredGraph) addProperty(entity int, name string, value PropertyValue) {
+	name = stripSchemaOrg(name)
+	g.data.Entities[entity].Properties[name] = append(g.data.Entities[entity].Properties[name], value)
+}
+
+// walkJSONLD parses every <script type="application/ld+json"> block
+func (g *structuredGraph) walkJSONLD(n *html.Node) {
+	if n.Type == html.ElementNode && n.Data == "script" {
+		if scriptType, _ := getAttr(n, "type"); strings.EqualFold(strings.TrimSpace(scriptType), "application/ld+json") {
+			g.parseJSONLD(n)
+		}
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		g.walkJSONLD(c)
+	}
+}
+
+// parseJSONLD adds the entities of one JSON-LD block, or records its syntax error
+func (g *structuredGraph) parseJSONLD(script *html.Node) {
+	path := elementPath(script)
+	raw := []byte(nodeText(script))
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		g.data.Errors = append(g.data.Errors, StructuredDataError{Format: formatJSONLD, Path: path, Message: jsonErrorMessage(raw, err)})
+		return
+	}
+	// Decode stops after the first value; browsers and search engines reject anything after it
+	if _, err := decoder.Token(); err != io.EOF {
+		message := fmt.Sprintf("invalid JSON at line %d: unexpected data after top-level value", lineAt(raw, decoder.InputOffset()))
+		if err != nil {
+			message = jsonErrorMessage(raw, err)
+		}
+		g.data.Errors = append(g.data.Errors, StructuredDataError{Format: formatJSONLD, Path: path, Message: message})
+		return
+	}
+
+	for _, node := range topLevelNodes(document) {
+		if object, ok := node.(map[string]any); ok {
+			g.jsonLDEntity(object, path)
+		}
+	}
+}
+
+// topLevelNodes unwraps arrays and @graph containers into the nodes they hold
+func topLevelNodes(document any) []any {
+	switch value := document.(type) {
+	case []any:
+		var nodes []any
+		for _, item := range value {
+			nodes = append(nodes, topLevelNodes(item)...)
+		}
+		return nodes
+	case map[string]any:
+		if graph, ok := value["@graph"]; ok {
+			return topLevelNodes(graph)
+		}
+		return []any{value}
+	}
+	return nil
+}
+
+// jsonLDEntity adds a JSON-LD node object and its nested nodes, and returns its ID
+func (g *structuredGraph) jsonLDEntity(object map[string]any, path string) string {
+	id, _ := object["@id"].(string)
+	entity := g.newEntity(formatJSONLD, path, id, jsonLDStrings(object["@type"]))
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		if !strings.HasPrefix(key, "@") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		values, ok := object[key].([]any)
+		if !ok {
+			values = []any{object[key]}
+		}
+		for _, value := range values {
+			if property, ok := g.jsonLDValue(value, path); ok {
+				g.addProperty(entity, key, property)
+			}
+		}
+	}
+	return g.data.Entities[entity].ID
+}
+
+// jsonLDValue converts a JSON-LD value to a literal, or adds a nested node and references it
+func (g *structuredGraph) jsonLDValue(value any, path string) (PropertyValue, bool) {
+	switch v := value.(type) {
+	case string:
+		return PropertyValue{Value: v}, true
+	case json.Number:
+		return PropertyValue{Value: v.String()}, true
+	case bool:
+		return PropertyValue{Value: strconv.FormatBool(v)}, true
+	case map[string]any:
+		if literal, ok := v["@value"]; ok {
+			return g.jsonLDValue(literal, path)
+		}
+		// A node with nothing but an @id points at an entity declared elsewhere
+		if id, ok := v["@id"].(string); ok && len(v) == 1 {
+			return PropertyValue{Ref: id}, true
+		}
+		return PropertyValue{Ref: g.jsonLDEntity(v, path)}, true
+	}
+	return PropertyValue{}, false
+}
+
+// jsonLDStrings reads a value that may be a string or an array of strings, such as @type
+func jsonLDStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// walkMarkup reads Microdata or RDFa Lite items; current is the index of the enclosing entity, if any
+func (g *structuredGraph) walkMarkup(n *html.Node, syntax markupSyntax, current *int) {
+	if n.Type == html.ElementNode {
+		properties, _ := getAttr(n, syntax.property)
+		names := strings.Fields(properties)
+
+		if _, scoped := getAttr(n, syntax.scope); scoped {
+			types, _ := getAttr(n, syntax.types)
+			id, _ := getAttr(n, syntax.id)
+			entity := g.newEntity(syntax.format, elementPath(n), id, strings.Fields(types))
+			if current != nil {
+				for _, name := range names {
+					g.addProperty(*current, name, PropertyValue{Ref: g.data.Entities[entity].ID})
+				}
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				g.walkMarkup(c, syntax, &entity)
+			}
+			return
+		}
+
+		if current != nil && len(names) > 0 {
+			value := PropertyValue{Value: g.markupValue(n, syntax)}
+			for _, name := range names {
+				g.addProperty(*current, name, value)
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		g.walkMarkup(c, syntax, current)
+	}
+}
+
+// markupValue returns the value of a property element: its content attribute, a URL attribute, a machine-readable value or its text
+func (g *structuredGraph) markupValue(n *html.Node, syntax markupSyntax) string {
+	if content, ok := getAttr(n, "content"); ok {
+		return content
+	}
+	if syntax.format == formatRDFa {
+		if resource, ok := getAttr(n, "resource"); ok {
+			return resolveURL(g.base, resource)
+		}
+	}
+
+	var attr string
+	switch n.Data {
+	case "a", "area", "link":
+		attr = "href"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		attr = "src"
+	case "object":
+		attr = "data"
+	case "data", "meter":
+		attr = "value"
+	case "time":
+		attr = "datetime"
+	}
+	if value, ok := getAttr(n, attr); ok && attr != "" {
+		if attr == "href" || attr == "src" || attr == "data" {
+			return resolveURL(g.base, value)
+		}
+		return value
+	}
+	return textContent(n)
+}
+
+// missingProperties returns the required properties of the entity's types that it lacks
+func missingProperties(entity Entity) []string {
+	var missing []string
+	for _, t := range entity.Types {
+		for _, required := range requiredProperties[t] {
+			present := false
+			for _, name := range strings.Split(required, "|") {
+				if hasValue(entity.Properties[name]) {
+					present = true
+					break
+				}
+			}
+			if !present && !slices.Contains(missing, required) {
+				missing = append(missing, required)
+			}
+		}
+	}
+	return missing
+}
+
+// hasValue reports whether any of the values is a reference or a non-empty literal
+func hasValue(values []PropertyValue) bool {
+	for _, value := range values {
+		if value.Ref != "" || strings.TrimSpace(value.Value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// stripSchemaOrg turns a schema.org URL or prefixed name into its short name
+func stripSchemaOrg(name string) string {
+	for _, prefix := range schemaOrgPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix)
+		}
+	}
+	return name
+}
+
+// jsonErrorMessage describes a JSON syntax error with the line it occurred on
+func jsonErrorMessage(raw []byte, err error) string {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Sprintf("invalid JSON at line %d: %v", lineAt(raw, syntaxErr.Offset), err)
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return "invalid JSON: unexpected end of input"
+	}
+	return fmt.Sprintf("invalid JSON: %v", err)
+}
+
+// lineAt returns the 1-based line of a byte offset in raw
+func lineAt(raw []byte, offset int64) int {
+	return bytes.Count(raw[:min(int(offset), len(raw))], []byte("\n")) + 1
+}
@@ -0,0 +1,230 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+func extractStructuredData(t *testing.T, htmlContent string) StructuredData {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(htmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+
+	var result models.AnalysisResponse
+	base, _ := url.Parse("https://example.com/shop/shoe")
+	if _, err := (&StructuredDataExtractor{}).Extract(context.Background(), doc, base, &result, htmlContent); err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	data, ok := models.GetSection[StructuredData](&result, structuredDataSection)
+	if !ok {
+		t.Fatalf("structured_data section missing: %+v", result.Sections)
+	}
+	return data
+}
+
+func entityByID(t *testing.T, data StructuredData, id string) Entity {
+	t.Helper()
+	for _, entity := range data.Entities {
+		if entity.ID == id {
+			return entity
+		}
+	}
+	t.Fatalf("entity %s not found in %+v", id, data.Entities)
+	return Entity{}
+}
+
+func TestStructuredDataExtractor_JSONLD(t *testing.T) {
+	htmlContent := `<html><head>
+		<script type="application/ld+json">
+		{
+			"@context": "https://schema.org",
+			"@graph": [
+				{
+					"@type": "Product",
+					"@id": "#shoe",
+					"name": "Running shoe",
+					"image": ["/a.png", "/b.png"],
+					"offers": {"@type": "Offer", "price": 59.9, "priceCurrency": "EUR", "seller": {"@id": "#org"}}
+				},
+				{"@type": "Organization", "@id": "#org", "name": "Example"}
+			]
+		}
+		</script>
+		<script type="application/ld+json">
+		{
+			"@type": "Article",
+			"headline": "Broken",
+		}
+		</script>
+		<script type="text/javascript">{"@type": "Product"}</script>
+	</head><body></body></html>`
+
+	data := extractStructuredData(t, htmlContent)
+
+	if len(data.Entities) != 3 {
+		t.Fatalf("Expected 3 entities, got %+v", data.Entities)
+	}
+
+	product := entityByID(t, data, "#shoe")
+	if product.Format != formatJSONLD || !reflect.DeepEqual(product.Types, []string{"Product"}) {
+		t.Errorf("Unexpected product: %+v", product)
+	}
+	if got := product.Properties["image"]; len(got) != 2 || got[1].Value != "/b.png" {
+		t.Errorf("Expected both images, got %+v", got)
+	}
+	if len(product.MissingProperties) != 0 {
+		t.Errorf("Expected no missing properties, got %v", product.MissingProperties)
+	}
+
+	// The nested offer is its own entity, referenced from the product
+	offerRef := product.Properties["offers"][0].Ref
+	offer := entityByID(t, data, offerRef)
+	if offer.Properties["price"][0].Value != "59.9" {
+		t.Errorf("Expected price 59.9, got %+v", offer.Properties["price"])
+	}
+	if offer.Properties["seller"][0].Ref != "#org" {
+		t.Errorf("Expected a reference to #org, got %+v", offer.Properties["seller"])
+	}
+
+	organization := entityByID(t, data, "#org")
+	if !reflect.DeepEqual(organization.MissingProperties, []string{"url"}) {
+		t.Errorf("Expected url to be missing, got %v", organization.MissingProperties)
+	}
+
+	if len(data.Errors) != 1 {
+		t.Fatalf("Expected one syntax error, got %+v", data.Errors)
+	}
+	if !strings.Contains(data.Errors[0].Message, "line 5") || data.Errors[0].Path != "html > head > script:nth-of-type(2)" {
+		t.Errorf("Unexpected syntax error: %+v", data.Errors[0])
+	}
+}
+
+func TestStructuredDataExtractor_JSONLDTrailingData(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		message string
+	}{
+		{"extra brace", `{"@type":"Organization","name":"Acme"}}`, "line 1: invalid character '}'"},
+		{"second value", "{\"@type\":\"Organization\",\"name\":\"Acme\"}\n{\"@type\":\"Person\"}", "line 2: unexpected data after top-level value"},
+		{"trailing whitespace", "{\"@type\":\"Organization\",\"name\":\"Acme\"}\n\t\n", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := extractStructuredData(t, `<html><head><script type="application/ld+json">`+tt.script+`</script></head></html>`)
+
+			if tt.message == "" {
+				if len(data.Errors) != 0 || len(data.Entities) != 1 {
+					t.Errorf("Expected one entity and no errors, got %+v", data)
+				}
+				return
+			}
+			if len(data.Errors) != 1 || !strings.Contains(data.Errors[0].Message, tt.message) {
+				t.Fatalf("Expected an error containing %q, got %+v", tt.message, data.Errors)
+			}
+			if len(data.Entities) != 0 {
+				t.Errorf("Expected the invalid block to be skipped, got %+v", data.Entities)
+			}
+		})
+	}
+}
+
+func TestStructuredDataExtractor_Microdata(t *testing.T) {
+	htmlContent := `<html><body>
+		<div itemscope itemtype="https://schema.org/Product">
+			<h1 itemprop="name">Running <b>shoe</b></h1>
+			<img itemprop="image" src="/img/shoe.png" alt="">
+			<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+				<meta itemprop="priceCurrency" content="EUR">
+				<data itemprop="price" value="59.90">59,90 €</data>
+			</div>
+		</div>
+		<ol itemscope itemtype="https://schema.org/BreadcrumbList">
+			<li itemprop="itemListElement" itemscope itemtype="https://schema.org/ListItem">
+				<a itemprop="item" href="/shop"><span itemprop="name">Shop</span></a>
+			</li>
+		</ol>
+	</body></html>`
+
+	data := extractStructuredData(t, htmlContent)
+
+	if len(data.Entities) != 4 {
+		t.Fatalf("Expected 4 entities, got %+v", data.Entities)
+	}
+
+	product := entityByID(t, data, "_:b0")
+	if product.Format != formatMicrodata || product.Path != "html > body > div" {
+		t.Errorf("Unexpected product: %+v", product)
+	}
+	if product.Properties["name"][0].Value != "Running shoe" {
+		t.Errorf("Expected name from text content, got %+v", product.Properties["name"])
+	}
+	if product.Properties["image"][0].Value != "https://example.com/img/shoe.png" {
+		t.Errorf("Expected resolved image URL, got %+v", product.Properties["image"])
+	}
+
+	offer := entityByID(t, data, product.Properties["offers"][0].Ref)
+	if offer.Properties["price"][0].Value != "59.90" || offer.Properties["priceCurrency"][0].Value != "EUR" {
+		t.Errorf("Unexpected offer: %+v", offer.Properties)
+	}
+
+	item := entityByID(t, data, "_:b3")
+	if item.Properties["item"][0].Value != "https://example.com/shop" {
+		t.Errorf("Expected resolved item URL, got %+v", item.Properties["item"])
+	}
+	if !reflect.DeepEqual(item.MissingProperties, []string{"position"}) {
+		t.Errorf("Expected position to be missing, got %v", item.MissingProperties)
+	}
+}
+
+func TestStructuredDataExtractor_RDFa(t *testing.T) {
+	htmlContent := `<html><body>
+		<article vocab="https://schema.org/" typeof="Article">
+			<h1 property="headline">Release notes</h1>
+			<time property="datePublished" datetime="2024-05-01">May 1</time>
+			<div property="author" typeof="Person"><span property="name">Ada</span></div>
+		</article>
+		<p property="name">Outside any entity</p>
+	</body></html>`
+
+	data := extractStructuredData(t, htmlContent)
+
+	if len(data.Entities) != 2 {
+		t.Fatalf("Expected 2 entities, got %+v", data.Entities)
+	}
+
+	article := data.Entities[0]
+	if article.Format != formatRDFa || !reflect.DeepEqual(article.Types, []string{"Article"}) {
+		t.Errorf("Unexpected article: %+v", article)
+	}
+	if article.Properties["datePublished"][0].Value != "2024-05-01" {
+		t.Errorf("Expected datetime value, got %+v", article.Properties["datePublished"])
+	}
+	if !reflect.DeepEqual(article.MissingProperties, []string{"image"}) {
+		t.Errorf("Expected image to be missing, got %v", article.MissingProperties)
+	}
+
+	author := entityByID(t, data, article.Properties["author"][0].Ref)
+	if author.Properties["name"][0].Value != "Ada" {
+		t.Errorf("Unexpected author: %+v", author)
+	}
+}
+
+func TestStructuredDataExtractor_NoMarkup(t *testing.T) {
+	data := extractStructuredData(t, `<html><body><p>Plain page</p></body></html>`)
+
+	if data.Entities == nil || len(data.Entities) != 0 || len(data.Errors) != 0 {
+		t.Errorf("Expected an empty graph, got %+v", data)
+	}
+}
@@ -1,6 +1,7 @@
 package extractors
 
 import (
+	"context"
 	"net/url"
 	"strings"
 
@@ -18,25 +19,32 @@ func (e *TitleExtractor) Name() string {
 }
 
 // Extract finds and extracts the page title from the HTML document
-func (e *TitleExtractor) Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) {
-	if result.PageTitle != "" {
-		return // Already found
+func (e *TitleExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if result.PageTitle != nil && *result.PageTitle != "" {
+		return nil, nil // Already found
 	}
 
+	title := ""
 	var walk func(*html.Node)
 	walk = func(n *html.Node) {
 		if n.Type == html.ElementNode && n.Data == "title" && n.FirstChild != nil {
-			result.PageTitle = normalizeText(n.FirstChild.Data)
+			title = normalizeText(n.FirstChild.Data)
 			return
 		}
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
 			walk(c)
-			if result.PageTitle != "" {
+			if title != "" {
 				return
 			}
 		}
 	}
 	walk(doc)
+	result.PageTitle = &title
+
+	if title == "" {
+		return []string{"no non-empty <title> element found"}, nil
+	}
+	return nil, nil
 }
 
 // normalizeText cleans and normalizes text content
@@ -1,6 +1,7 @@
 package extractors
 
 import (
+	"context"
 	"net/url"
 	"strings"
 
@@ -18,12 +19,18 @@ func (e *VersionExtractor) Name() string {
 }
 
 // Extract detects the HTML version from DOCTYPE
-func (e *VersionExtractor) Extract(doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) {
-	if result.HTMLVersion != "" {
-		return // Already found
+func (e *VersionExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if result.HTMLVersion != nil {
+		return nil, nil // Already found
 	}
 
-	result.HTMLVersion = DetectHTMLVersion(rawHTML)
+	version := DetectHTMLVersion(rawHTML)
+	result.HTMLVersion = &version
+
+	if version == "Unknown" {
+		return []string{"no DOCTYPE declaration found"}, nil
+	}
+	return nil, nil
 }
 
 // DetectHTMLVersion detects the HTML version from DOCTYPE in raw HTML
@@ -0,0 +1,164 @@
+package analyzer
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+)
+
+// reportedHeaders are the response headers copied into the analysis, when present
+var reportedHeaders = []string{
+	"Cache-Control",
+	"Content-Language",
+	"Content-Security-Policy",
+	"ETag",
+	"Expires",
+	"Last-Modified",
+	"Referrer-Policy",
+	"Server",
+	"Strict-Transport-Security",
+	"Vary",
+	"X-Content-Type-Options",
+	"X-Frame-Options",
+	"X-Powered-By",
+	"X-Robots-Tag",
+}
+
+// fetchedPage is a fetched document, transcoded to UTF-8, with what was learned while fetching it
+type fetchedPage struct {
+	html      string
+	charset   *models.Charset
+	response  *models.HTTPResponse
+	redirects *models.Redirects
+	timing    *models.FetchTiming
+}
+
+// fetchHTML fetches HTML content (shared implementation)
+func (s *AnalyzerService) fetchHTML(ctx context.Context, u *url.URL) (*fetchedPage, error) {
+	trace := newFetchTrace()
+	if s.config.robots != nil {
+		if err := s.config.robots.Check(ctx, u); err != nil {
+			return nil, err
+		}
+	}
+
+	chain := &redirectChain{}
+	fetchCtx := httptrace.WithClientTrace(withRedirectChain(ctx, chain), trace.clientTrace())
+	req, err := http.NewRequestWithContext(fetchCtx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, domainerrors.ClassifyNetworkError(u.String(), err)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	// Asking for compression ourselves keeps the transport from decoding it, so the wire size can be measured
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, domainerrors.ClassifyNetworkError(u.String(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, domainerrors.ClassifyHTTPStatusError(u.String(), resp.StatusCode)
+	}
+
+	wire := &countingReader{r: resp.Body}
+	body, err := decompress(wire, resp.Header.Get("Content-Encoding"))
+	if err != nil {
+		return nil, domainerrors.NewHTMLParseError(u.String(), err)
+	}
+
+	maxSize := s.MaxBodySize()
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		// Errors that didn't come from the connection come from a corrupt compressed body
+		if wire.err == nil || wire.err == io.EOF {
+			return nil, domainerrors.NewHTMLParseError(u.String(), err)
+		}
+		return nil, domainerrors.ClassifyNetworkError(u.String(), err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, domainerrors.NewContentTooBigError(u.String(), int64(len(data)), maxSize)
+	}
+	trace.bodyDone()
+
+	raw, detected, err := decodeHTML(data, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, domainerrors.NewHTMLParseError(u.String(), err)
+	}
+
+	return &fetchedPage{
+		html:      raw,
+		charset:   detected,
+		response:  describeResponse(resp, wire.n, int64(len(data))),
+		redirects: chain.summary(resp.Request.URL),
+		timing:    trace.timing(),
+	}, nil
+}
+
+// describeResponse collects the metadata of the final response of a fetch
+func describeResponse(resp *http.Response, transferSize, bodySize int64) *models.HTTPResponse {
+	info := &models.HTTPResponse{
+		FinalURL:        resp.Request.URL.String(),
+		StatusCode:      resp.StatusCode,
+		Protocol:        resp.Proto,
+		ContentType:     resp.Header.Get("Content-Type"),
+		ContentEncoding: resp.Header.Get("Content-Encoding"),
+		TransferSize:    transferSize,
+		BodySize:        bodySize,
+	}
+	if resp.ContentLength >= 0 {
+		length := resp.ContentLength
+		info.ContentLength = &length
+	}
+
+	for _, name := range reportedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			if info.Headers == nil {
+				info.Headers = make(map[string]string)
+			}
+			info.Headers[name] = strings.Join(values, ", ")
+		}
+	}
+	return info
+}
+
+// decompress wraps a response body in a decoder for its Content-Encoding
+func decompress(body io.Reader, contentEncoding string) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		return gzip.NewReader(body)
+	case "deflate":
+		return zlib.NewReader(body)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+}
+
+// countingReader counts the bytes read through it and remembers the last read error
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	if err != nil {
+		c.err = err
+	}
+	return n, err
+}
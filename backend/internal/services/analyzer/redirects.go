@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
+)
+
+// redirectChainKey is the context key of the redirect chain of a fetch
+type redirectChainKey struct{}
+
+// redirectChain records the redirects of one fetch; the client follows them one at a time
+type redirectChain struct {
+	hops []models.RedirectHop
+}
+
+// withRedirectChain attaches a chain for the redirect policy to record into
+func withRedirectChain(ctx context.Context, chain *redirectChain) context.Context {
+	return context.WithValue(ctx, redirectChainKey{}, chain)
+}
+
+// urls returns the URLs of the chain followed by the given next URL
+func (c *redirectChain) urls(next *url.URL) []string {
+	urls := make([]string, 0, len(c.hops)+1)
+	for _, hop := range c.hops {
+		urls = append(urls, hop.URL)
+	}
+	return append(urls, next.String())
+}
+
+// summary describes the chain that ended at the final URL and flags suspicious hops
+func (c *redirectChain) summary(final *url.URL) *models.Redirects {
+	redirects := &models.Redirects{Hops: c.hops}
+	if redirects.Hops == nil {
+		redirects.Hops = []models.RedirectHop{}
+	}
+
+	urls := c.urls(final)
+	seen := make(map[string]bool, len(urls))
+	for i, raw := range urls {
+		if seen[raw] {
+			redirects.Loop = true
+		}
+		seen[raw] = true
+		if i == 0 {
+			continue
+		}
+
+		from, errFrom := url.Parse(urls[i-1])
+		to, errTo := url.Parse(raw)
+		if errFrom != nil || errTo != nil {
+			continue
+		}
+		if from.Scheme == "https" && to.Scheme == "http" {
+			redirects.HTTPSDowngrade = true
+		}
+		if isWWWSwitch(from.Hostname(), to.Hostname()) {
+			redirects.MixedWWW = true
+		}
+	}
+	return redirects
+}
+
+// isWWWSwitch reports whether two hosts are the www and apex variants of one domain
+func isWWWSwitch(a, b string) bool {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+	return a != b && strings.TrimPrefix(a, "www.") == strings.TrimPrefix(b, "www.")
+}
+
+// redirectPolicy creates a redirect policy function that records every hop in the request's chain
+// Redirect targets are checked against robots.txt when a robots service is given
+func redirectPolicy(maxRedirects int, robotsService *robots.RobotsService) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		chain, _ := req.Context().Value(redirectChainKey{}).(*redirectChain)
+		if chain == nil {
+			chain = &redirectChain{}
+		}
+		hop := models.RedirectHop{URL: via[len(via)-1].URL.String()}
+		if req.Response != nil {
+			hop.StatusCode = req.Response.StatusCode
+			hop.Location = req.Response.Header.Get("Location")
+		}
+		chain.hops = append(chain.hops, hop)
+
+		// via holds the original request and every redirect followed so far
+		if len(via) > maxRedirects {
+			return domainerrors.NewTooManyRedirectsError(via[0].URL.String(), maxRedirects, chain.urls(req.URL), chain.summary(req.URL).Loop)
+		}
+		if robotsService != nil {
+			ctx, cancel := untraced(req.Context())
+			defer cancel()
+			return robotsService.Check(ctx, req.URL)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,180 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+)
+
+// Registry indexes the configured extractors by Extractor.Name(), keeping registration order
+type Registry struct {
+	extractors []Extractor
+	byName     map[string]Extractor
+}
+
+// NewRegistry creates a registry; extractor and section names must be non-empty and unique,
+// and dependencies must name registered extractors without forming a cycle
+func NewRegistry(extractors ...Extractor) (*Registry, error) {
+	registry := &Registry{
+		extractors: make([]Extractor, 0, len(extractors)),
+		byName:     make(map[string]Extractor, len(extractors)),
+	}
+
+	for _, extractor := range extractors {
+		name := extractor.Name()
+		if name == "" {
+			return nil, fmt.Errorf("extractor %T has an empty name", extractor)
+		}
+		if _, exists := registry.byName[name]; exists {
+			return nil, fmt.Errorf("duplicate extractor name %q", name)
+		}
+		registry.byName[name] = extractor
+		registry.extractors = append(registry.extractors, extractor)
+	}
+
+	sectionOwners := make(map[string]string)
+	for _, extractor := range registry.extractors {
+		section, ok := sectionOf(extractor)
+		if !ok {
+			continue
+		}
+		if section.Name == "" || section.Type == nil {
+			return nil, fmt.Errorf("extractor %q declares a section without a name or type", extractor.Name())
+		}
+		if owner, exists := sectionOwners[section.Name]; exists {
+			return nil, fmt.Errorf("section %q is owned by both %q and %q", section.Name, owner, extractor.Name())
+		}
+		sectionOwners[section.Name] = extractor.Name()
+	}
+
+	for _, extractor := range registry.extractors {
+		for _, dep := range dependencies(extractor) {
+			if _, ok := registry.byName[dep]; !ok {
+				return nil, fmt.Errorf("extractor %q depends on unknown extractor %q", extractor.Name(), dep)
+			}
+		}
+	}
+	if cycle := registry.findCycle(); cycle != nil {
+		return nil, fmt.Errorf("extractor dependency cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	return registry, nil
+}
+
+// Names returns the registered extractor names in registration order
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.extractors))
+	for i, extractor := range r.extractors {
+		names[i] = extractor.Name()
+	}
+	return names
+}
+
+// Sections returns the extension sections declared by the registered extractors
+func (r *Registry) Sections() []models.SectionSchema {
+	var sections []models.SectionSchema
+	for _, extractor := range r.extractors {
+		if section, ok := sectionOf(extractor); ok {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// Select returns the named extractors and their dependencies in registration order;
+// no names selects all of them. Dependencies that weren't named are run for their
+// dependents only, and their sections are left out of the response
+// Unknown names are rejected with a validation error listing the available extractors
+func (r *Registry) Select(names []string) ([]Extractor, error) {
+	if len(names) == 0 {
+		return r.extractors, nil
+	}
+
+	selected := make(map[string]bool, len(names))
+	var include func(name string)
+	include = func(name string) {
+		if selected[name] {
+			return
+		}
+		selected[name] = true
+		for _, dep := range dependencies(r.byName[name]) {
+			include(dep)
+		}
+	}
+	for _, name := range names {
+		if _, ok := r.byName[name]; !ok {
+			return nil, domainerrors.NewInvalidInputError("extractors", name,
+				fmt.Sprintf("unknown extractor, available: %s", strings.Join(r.Names(), ", ")))
+		}
+		include(name)
+	}
+
+	extractors := make([]Extractor, 0, len(selected))
+	for _, extractor := range r.extractors {
+		if selected[extractor.Name()] {
+			extractors = append(extractors, extractor)
+		}
+	}
+	return extractors, nil
+}
+
+// findCycle returns the extractor names along a dependency cycle, or nil if there is none
+func (r *Registry) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(r.extractors))
+
+	var path []string
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string(nil), path[i:]...), name)
+				}
+			}
+		case done:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range dependencies(r.byName[name]) {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, extractor := range r.extractors {
+		if cycle := visit(extractor.Name()); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// dependencies returns the extractors an extractor depends on, if it declares any
+func dependencies(extractor Extractor) []string {
+	if dependent, ok := extractor.(Dependent); ok {
+		return dependent.DependsOn()
+	}
+	return nil
+}
+
+// sectionOf returns the extension section an extractor owns, if any
+func sectionOf(extractor Extractor) (models.SectionSchema, bool) {
+	if owner, ok := extractor.(SectionOwner); ok {
+		return owner.Section(), true
+	}
+	return models.SectionSchema{}, false
+}
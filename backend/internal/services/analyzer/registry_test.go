@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+
+	"golang.org/x/net/html"
+)
+
+// stubExtractor is a configurable extractor with optional dependencies
+type stubExtractor struct {
+	name string
+	deps []string
+	run  func(ctx context.Context, result *models.AnalysisResponse) ([]string, error)
+}
+
+func (e *stubExtractor) Name() string { return e.name }
+
+func (e *stubExtractor) DependsOn() []string { return e.deps }
+
+func (e *stubExtractor) Extract(ctx context.Context, doc *html.Node, base *url.URL, result *models.AnalysisResponse, rawHTML string) ([]string, error) {
+	if e.run == nil {
+		return nil, nil
+	}
+	return e.run(ctx, result)
+}
+
+// sectionExtractor is a stub extractor that owns an extension section
+type sectionExtractor struct {
+	stubExtractor
+	section string
+}
+
+func (e *sectionExtractor) Section() models.SectionSchema {
+	return models.SectionSchema{Name: e.section, Type: map[string]int{}}
+}
+
+func TestRegistry_Select(t *testing.T) {
+	registry, err := NewRegistry(
+		&extractors.TitleExtractor{},
+		&extractors.HeadingsExtractor{},
+		&extractors.LinksExtractor{},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	if got := strings.Join(registry.Names(), ","); got != "title,headings,links" {
+		t.Errorf("Names() = %v, want registration order", got)
+	}
+
+	all, err := registry.Select(nil)
+	if err != nil || len(all) != 3 {
+		t.Errorf("Select(nil) = %d extractors, %v; want all 3", len(all), err)
+	}
+
+	// Selection follows registration order and ignores duplicates
+	selected, err := registry.Select([]string{"headings", "title", "headings"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if len(selected) != 2 || selected[0].Name() != "title" || selected[1].Name() != "headings" {
+		t.Errorf("Select returned %v, want [title headings]", selected)
+	}
+
+	_, err = registry.Select([]string{"title", "seo"})
+	if !domainerrors.IsInputValidationError(err) {
+		t.Errorf("Select with unknown name: got %v, want validation error", err)
+	}
+}
+
+func TestRegistry_DuplicateNames(t *testing.T) {
+	_, err := NewRegistry(&extractors.TitleExtractor{}, &extractors.TitleExtractor{})
+	if err == nil || !strings.Contains(err.Error(), "duplicate extractor name") {
+		t.Errorf("Expected duplicate name error, got: %v", err)
+	}
+}
+
+func TestRegistry_Dependencies(t *testing.T) {
+	_, err := NewRegistry(&stubExtractor{name: "a", deps: []string{"missing"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown extractor") {
+		t.Errorf("Expected unknown dependency error, got: %v", err)
+	}
+
+	_, err = NewRegistry(
+		&stubExtractor{name: "a", deps: []string{"b"}},
+		&stubExtractor{name: "b", deps: []string{"c"}},
+		&stubExtractor{name: "c", deps: []string{"a"}},
+	)
+	if err == nil || !strings.Contains(err.Error(), "a -> b -> c -> a") {
+		t.Errorf("Expected dependency cycle error, got: %v", err)
+	}
+
+	registry, err := NewRegistry(
+		&extractors.TitleExtractor{},
+		&stubExtractor{name: "outline", deps: []string{"headings"}},
+		&extractors.HeadingsExtractor{},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	// Selecting a dependent extractor pulls in its dependencies
+	selected, err := registry.Select([]string{"outline"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if len(selected) != 2 || selected[0].Name() != "outline" || selected[1].Name() != "headings" {
+		t.Errorf("Select returned %v, want [outline headings]", selected)
+	}
+}
+
+func TestRegistry_Sections(t *testing.T) {
+	registry, err := NewRegistry(
+		&extractors.TitleExtractor{},
+		&extractors.CSRExtractor{},
+		&sectionExtractor{stubExtractor: stubExtractor{name: "seo"}, section: "seo"},
+	)
+	if err != nil {
+		t.Fatalf("Failed to create registry: %v", err)
+	}
+
+	var names []string
+	for _, section := range registry.Sections() {
+		names = append(names, section.Name)
+	}
+	if got := strings.Join(names, ","); got != "csr,seo" {
+		t.Errorf("Sections() = %v, want csr,seo", got)
+	}
+
+	_, err = NewRegistry(
+		&sectionExtractor{stubExtractor: stubExtractor{name: "a"}, section: "shared"},
+		&sectionExtractor{stubExtractor: stubExtractor{name: "b"}, section: "shared"},
+	)
+	if err == nil || !strings.Contains(err.Error(), `section "shared" is owned by both "a" and "b"`) {
+		t.Errorf("Expected duplicate section error, got: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+
+	"golang.org/x/net/html"
+)
+
+// extraction is the outcome of a single extractor run
+type extraction struct {
+	warnings []string
+	err      error
+	panicked bool
+}
+
+// runExtractors runs the extractors concurrently and returns their diagnostics in the given order
+// An extractor starts once every dependency it declares has finished. Each one works on a
+// scratch copy of the result that is merged back only if it returned in time without panicking,
+// so a misbehaving extractor can't corrupt the other sections
+// The copy is shallow: it shares the values of the other sections, which is why extractors
+// must store newly allocated values and never modify the ones they read
+// Implicit extractors only run for their dependents: once all are done, the sections they produced are removed
+func runExtractors(ctx context.Context, extractors []Extractor, doc *html.Node, base *url.URL, result *models.AnalysisResponse, raw string, timeout time.Duration, implicit map[string]bool) []models.ExtractorDiagnostic {
+	// owners maps each output to the extractor that produced it first
+	owners := make(map[string]string)
+	finished := make(map[string]chan struct{}, len(extractors))
+	for _, extractor := range extractors {
+		finished[extractor.Name()] = make(chan struct{})
+	}
+
+	diagnostics := make([]models.ExtractorDiagnostic, len(extractors))
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for i, extractor := range extractors {
+		wg.Add(1)
+		go func(i int, extractor Extractor) {
+			defer wg.Done()
+			defer close(finished[extractor.Name()])
+
+			for _, dep := range dependencies(extractor) {
+				if ch, ok := finished[dep]; ok {
+					select {
+					case <-ch:
+					case <-ctx.Done():
+					}
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				diagnostics[i] = newDiagnostic(extractor.Name(), 0, extraction{err: err})
+				return
+			}
+
+			// The snapshot carries the sections of finished dependencies; the sections map is
+			// cloned under the lock because other extractors keep merging into it
+			mu.Lock()
+			before := *result
+			before.Sections = maps.Clone(result.Sections)
+			mu.Unlock()
+			scratch := before
+			scratch.Sections = maps.Clone(before.Sections)
+
+			start := time.Now()
+			outcome, ok := runIsolated(ctx, timeout, extractor, doc, base, &scratch, raw)
+			if !ok {
+				diagnostics[i] = models.ExtractorDiagnostic{
+					Name:     extractor.Name(),
+					Status:   models.ExtractorStatusTimeout,
+					Duration: milliseconds(time.Since(start)),
+					Messages: []string{fmt.Sprintf("timed out after %v", timeout)},
+				}
+				return
+			}
+			diagnostics[i] = newDiagnostic(extractor.Name(), time.Since(start), outcome)
+
+			if !outcome.panicked {
+				mu.Lock()
+				for _, output := range mergeSections(result, &before, &scratch, extractor) {
+					if _, ok := owners[output]; !ok {
+						owners[output] = extractor.Name()
+					}
+				}
+				mu.Unlock()
+			}
+		}(i, extractor)
+	}
+	wg.Wait()
+
+	for output, owner := range owners {
+		if implicit[owner] {
+			dropOutput(result, output)
+		}
+	}
+	for i := range diagnostics {
+		diagnostics[i].Implicit = implicit[diagnostics[i].Name]
+	}
+	return diagnostics
+}
+
+// runIsolated runs one extractor inside its own panic boundary and time budget
+// It reports false if the extractor didn't return in time; the extractor is then
+// left to notice its cancelled context while its scratch result is discarded
+func runIsolated(ctx context.Context, timeout time.Duration, extractor Extractor, doc *html.Node, base *url.URL, scratch *models.AnalysisResponse, raw string) (extraction, bool) {
+	extractorCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan extraction, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("extractor %s panicked: %v\n%s", extractor.Name(), r, debug.Stack())
+				done <- extraction{err: fmt.Errorf("panic: %v", r), panicked: true}
+			}
+		}()
+
+		warnings, err := extractor.Extract(extractorCtx, doc, base, scratch, raw)
+		done <- extraction{warnings: warnings, err: err}
+	}()
+
+	select {
+	case outcome := <-done:
+		return outcome, true
+	case <-extractorCtx.Done():
+		return extraction{}, false
+	}
+}
+
+// Outputs of the core fields, as named in the JSON response
+const (
+	outputHTMLVersion  = "html_version"
+	outputPageTitle    = "page_title"
+	outputHeadings     = "headings"
+	outputLinks        = "links"
+	outputHasLoginForm = "has_login_form"
+
+	// sectionOutputPrefix marks extension sections, so they can't clash with the core fields
+	sectionOutputPrefix = "sections."
+)
+
+// mergeSections copies the sections an extractor set on its scratch copy into the result
+// and returns the outputs it copied
+// Of the extension sections only the one the extractor owns is taken
+func mergeSections(result, before, after *models.AnalysisResponse, extractor Extractor) []string {
+	var outputs []string
+	if after.HTMLVersion != before.HTMLVersion {
+		result.HTMLVersion = after.HTMLVersion
+		outputs = append(outputs, outputHTMLVersion)
+	}
+	if after.PageTitle != before.PageTitle {
+		result.PageTitle = after.PageTitle
+		outputs = append(outputs, outputPageTitle)
+	}
+	if after.Headings != before.Headings {
+		result.Headings = after.Headings
+		outputs = append(outputs, outputHeadings)
+	}
+	if after.Links != before.Links {
+		result.Links = after.Links
+		outputs = append(outputs, outputLinks)
+	}
+	if after.HasLoginForm != before.HasLoginForm {
+		result.HasLoginForm = after.HasLoginForm
+		outputs = append(outputs, outputHasLoginForm)
+	}
+	if section, ok := sectionOf(extractor); ok {
+		if value, ok := after.Sections[section.Name]; ok {
+			models.SetSection(result, section.Name, value)
+			outputs = append(outputs, sectionOutputPrefix+section.Name)
+		}
+	}
+	return outputs
+}
+
+// dropOutput removes an output returned by mergeSections from the result
+func dropOutput(result *models.AnalysisResponse, output string) {
+	switch output {
+	case outputHTMLVersion:
+		result.HTMLVersion = nil
+	case outputPageTitle:
+		result.PageTitle = nil
+	case outputHeadings:
+		result.Headings = nil
+	case outputLinks:
+		result.Links = nil
+	case outputHasLoginForm:
+		result.HasLoginForm = nil
+	default:
+		delete(result.Sections, strings.TrimPrefix(output, sectionOutputPrefix))
+		if len(result.Sections) == 0 {
+			result.Sections = nil
+		}
+	}
+}
+
+// newDiagnostic summarizes an extractor run; an error outranks warnings
+func newDiagnostic(name string, elapsed time.Duration, outcome extraction) models.ExtractorDiagnostic {
+	diagnostic := models.ExtractorDiagnostic{
+		Name:     name,
+		Status:   models.ExtractorStatusOK,
+		Duration: milliseconds(elapsed),
+		Messages: outcome.warnings,
+	}
+	if len(outcome.warnings) > 0 {
+		diagnostic.Status = models.ExtractorStatusWarning
+	}
+	if outcome.err != nil {
+		diagnostic.Status = models.ExtractorStatusError
+		diagnostic.Messages = append([]string{outcome.err.Error()}, outcome.warnings...)
+	}
+	return diagnostic
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/models"
+)
+
+// fetchTrace records the network phases of a fetch through httptrace hooks
+// Hooks may fire concurrently, e.g. for parallel dial attempts, so all state is guarded
+type fetchTrace struct {
+	mu sync.Mutex
+
+	start         time.Time
+	dnsStart      time.Time
+	connectStarts map[string]time.Time
+	tlsStart      time.Time
+	wroteRequest  time.Time
+	firstByte     time.Time
+	bodyRead      time.Time
+
+	dns, connect, tls, ttfb time.Duration
+}
+
+// newFetchTrace starts timing a fetch
+func newFetchTrace() *fetchTrace {
+	return &fetchTrace{
+		start:         time.Now(),
+		connectStarts: make(map[string]time.Time),
+	}
+}
+
+// clientTrace returns the hooks that feed the trace
+func (t *fetchTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.record(func() { t.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.record(func() { t.dns += since(t.dnsStart) })
+		},
+		ConnectStart: func(network, addr string) {
+			t.record(func() { t.connectStarts[network+addr] = time.Now() })
+		},
+		ConnectDone: func(network, addr string, err error) {
+			// Only the dial that succeeded counts; losing parallel attempts overlap with it
+			t.record(func() {
+				if err == nil {
+					t.connect += since(t.connectStarts[network+addr])
+				}
+			})
+		},
+		TLSHandshakeStart: func() {
+			t.record(func() { t.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.record(func() { t.tls += since(t.tlsStart) })
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.record(func() { t.wroteRequest = time.Now() })
+		},
+		GotFirstResponseByte: func() {
+			t.record(func() {
+				t.firstByte = time.Now()
+				t.ttfb += since(t.wroteRequest)
+			})
+		},
+	}
+}
+
+// bodyDone marks the end of the content download
+func (t *fetchTrace) bodyDone() {
+	t.record(func() { t.bodyRead = time.Now() })
+}
+
+// timing returns the recorded phases
+func (t *fetchTrace) timing() *models.FetchTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timing := &models.FetchTiming{
+		DNSLookup:       milliseconds(t.dns),
+		TCPConnect:      milliseconds(t.connect),
+		TLSHandshake:    milliseconds(t.tls),
+		TimeToFirstByte: milliseconds(t.ttfb),
+		Total:           milliseconds(time.Since(t.start)),
+	}
+	if !t.firstByte.IsZero() && !t.bodyRead.IsZero() {
+		timing.ContentDownload = milliseconds(t.bodyRead.Sub(t.firstByte))
+	}
+	return timing
+}
+
+func (t *fetchTrace) record(update func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	update()
+}
+
+// since is time.Since for a phase whose start may not have been seen
+func since(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	return time.Since(start)
+}
+
+// untraced returns a context that is cancelled with ctx but carries none of its values,
+// so side requests such as robots.txt fetches don't show up in the page's trace
+func untraced(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached, cancel := context.WithCancel(context.Background())
+	stop := context.AfterFunc(ctx, cancel)
+	return detached, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,121 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/validation"
+)
+
+const (
+	defaultMaxURLs     = 500
+	defaultConcurrency = 8
+)
+
+// BatchService analyzes many URLs in one call with bounded concurrency
+type BatchService struct {
+	analyzer     *analyzer.AnalyzerService
+	urlValidator *validation.URLValidator
+	mapper       *domainerrors.ErrorMapper
+	maxURLs      int
+	concurrency  int
+}
+
+// NewBatchService creates a new batch service on top of the analyzer service
+func NewBatchService(cfg *config.Config, analyzerService *analyzer.AnalyzerService) *BatchService {
+	maxURLs := cfg.Batch.MaxURLs
+	if maxURLs <= 0 {
+		maxURLs = defaultMaxURLs
+	}
+	concurrency := cfg.Batch.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return &BatchService{
+		analyzer:     analyzerService,
+		urlValidator: validation.NewURLValidator(),
+		mapper:       domainerrors.NewErrorMapper(),
+		maxURLs:      maxURLs,
+		concurrency:  concurrency,
+	}
+}
+
+// Analyze runs every URL through the analyzer and collects per-URL results
+// A failing URL is reported in its own entry and never fails the whole batch
+func (s *BatchService) Analyze(ctx context.Context, urls []string) (models.BatchAnalysisResponse, error) {
+	if err := s.Validate(urls); err != nil {
+		return models.BatchAnalysisResponse{}, err
+	}
+
+	start := time.Now()
+	results := make([]models.BatchAnalysisResult, len(urls))
+
+	// Semaphore bounds the number of analyses in flight
+	sem := make(chan struct{}, s.concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, rawURL := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, rawURL string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.analyzeOne(ctx, rawURL)
+		}(i, rawURL)
+	}
+	wg.Wait()
+
+	response := models.BatchAnalysisResponse{
+		Results: results,
+		Total:   len(results),
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			response.Failed++
+		} else {
+			response.Succeeded++
+		}
+	}
+	response.AnalysisTime = int64(time.Since(start) / time.Millisecond)
+
+	return response, nil
+}
+
+// Validate checks the size of a URL list; individual URLs are validated when analyzed
+func (s *BatchService) Validate(urls []string) error {
+	if len(urls) == 0 {
+		return domainerrors.NewInvalidInputError("urls", len(urls), "at least one URL is required")
+	}
+	if len(urls) > s.maxURLs {
+		return domainerrors.NewInvalidInputError("urls", len(urls), fmt.Sprintf("too many URLs (max %d)", s.maxURLs))
+	}
+	return nil
+}
+
+// analyzeOne validates and analyzes a single URL, mapping failures to HTTP errors
+func (s *BatchService) analyzeOne(ctx context.Context, rawURL string) models.BatchAnalysisResult {
+	if err := s.urlValidator.ValidateURL(rawURL); err != nil {
+		return models.BatchAnalysisResult{URL: rawURL, Error: s.toHTTPError(err)}
+	}
+
+	response, err := s.analyzer.Analyze(ctx, rawURL)
+	if err != nil {
+		return models.BatchAnalysisResult{URL: rawURL, Error: s.toHTTPError(err)}
+	}
+
+	return models.BatchAnalysisResult{URL: rawURL, Result: &response}
+}
+
+// toHTTPError maps a per-URL failure to the standard HTTP error shape
+func (s *BatchService) toHTTPError(err error) *models.HTTPError {
+	httpError := s.mapper.MapToHTTPError(err)
+	httpError.Timestamp = time.Now()
+	return httpError
+}
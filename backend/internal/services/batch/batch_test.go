@@ -0,0 +1,91 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatchService(t *testing.T, batchCfg config.BatchConfig) *BatchService {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 10,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+		Batch: batchCfg,
+	}
+
+	analyzerService, err := analyzer.NewAnalyzerService(cfg,
+		analyzer.WithExtractors(&extractors.TitleExtractor{}))
+	require.NoError(t, err)
+
+	return NewBatchService(cfg, analyzerService)
+}
+
+func TestBatchService_PerURLResults(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>OK Page</title></head><body></body></html>`))
+	})
+	mux.HandleFunc("/missing", http.NotFound)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	service := newTestBatchService(t, config.BatchConfig{Concurrency: 2})
+
+	urls := []string{ts.URL + "/ok", "ftp://invalid", ts.URL + "/missing", ts.URL + "/ok"}
+	response, err := service.Analyze(context.Background(), urls)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, response.Total)
+	assert.Equal(t, 2, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	require.Len(t, response.Results, 4)
+
+	// Results keep the input order
+	for i, r := range response.Results {
+		assert.Equal(t, urls[i], r.URL)
+	}
+
+	require.NotNil(t, response.Results[0].Result)
+	require.NotNil(t, response.Results[0].Result.PageTitle)
+	assert.Equal(t, "OK Page", *response.Results[0].Result.PageTitle)
+	assert.Nil(t, response.Results[0].Error)
+
+	require.NotNil(t, response.Results[1].Error)
+	assert.Equal(t, "INVALID_URL", response.Results[1].Error.Type)
+	assert.Equal(t, http.StatusBadRequest, response.Results[1].Error.Code)
+
+	require.NotNil(t, response.Results[2].Error)
+	assert.Equal(t, "HTTP_NOT_FOUND", response.Results[2].Error.Type)
+	assert.Nil(t, response.Results[2].Result)
+}
+
+func TestBatchService_Limits(t *testing.T) {
+	service := newTestBatchService(t, config.BatchConfig{MaxURLs: 2})
+
+	_, err := service.Analyze(context.Background(), nil)
+	assert.Error(t, err)
+
+	_, err = service.Analyze(context.Background(), []string{"https://a.com", "https://b.com", "https://c.com"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "too many URLs")
+}
+
+func TestNewBatchService_Defaults(t *testing.T) {
+	service := newTestBatchService(t, config.BatchConfig{})
+
+	assert.Equal(t, defaultMaxURLs, service.maxURLs)
+	assert.Equal(t, defaultConcurrency, service.concurrency)
+}
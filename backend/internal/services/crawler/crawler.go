@@ -0,0 +1,396 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
+	"github.com/steve-phan/page-insight-tool/internal/validation"
+)
+
+const (
+	defaultMaxDepth    = 3
+	defaultMaxPages    = 100
+	defaultConcurrency = 4
+)
+
+// CrawlerService analyzes a whole site by following internal links breadth-first
+type CrawlerService struct {
+	analyzer     *analyzer.AnalyzerService
+	robots       *robots.RobotsService
+	urlValidator *validation.URLValidator
+	mapper       *domainerrors.ErrorMapper
+	maxDepth     int
+	maxPages     int
+	concurrency  int
+}
+
+// target is a page waiting to be analyzed
+type target struct {
+	url   string
+	depth int
+}
+
+// pacer spaces out page fetches according to the site's Crawl-delay
+type pacer struct {
+	delay time.Duration
+	mu    sync.Mutex
+	next  time.Time
+}
+
+// NewCrawlerService creates a new crawler service on top of the analyzer service
+// A non-nil robots service makes crawls honor the site's Crawl-delay
+func NewCrawlerService(cfg *config.Config, analyzerService *analyzer.AnalyzerService, robotsService *robots.RobotsService) *CrawlerService {
+	maxDepth := cfg.Crawl.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
+	}
+	maxPages := cfg.Crawl.MaxPages
+	if maxPages <= 0 {
+		maxPages = defaultMaxPages
+	}
+	concurrency := cfg.Crawl.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	return &CrawlerService{
+		analyzer:     analyzerService,
+		robots:       robotsService,
+		urlValidator: validation.NewURLValidator(),
+		mapper:       domainerrors.NewErrorMapper(),
+		maxDepth:     maxDepth,
+		maxPages:     maxPages,
+		concurrency:  concurrency,
+	}
+}
+
+// crawlPlan is a validated crawl request with the server limits applied
+type crawlPlan struct {
+	seed     *url.URL
+	maxDepth int
+	maxPages int
+	include  []*regexp.Regexp
+	exclude  []*regexp.Regexp
+}
+
+// Validate checks the seed URL, limits and patterns of a crawl request without crawling
+func (s *CrawlerService) Validate(req models.CrawlRequest) error {
+	_, err := s.plan(req)
+	return err
+}
+
+// Crawl analyzes the seed URL and every internal page reachable from it within the limits
+// Pages are visited level by level, so the report lists them in breadth-first order
+// When the seed redirects to another host, e.g. to www, links to that host are followed as well
+func (s *CrawlerService) Crawl(ctx context.Context, req models.CrawlRequest) (models.CrawlReport, error) {
+	start := time.Now()
+
+	plan, err := s.plan(req)
+	if err != nil {
+		return models.CrawlReport{}, err
+	}
+	seed := plan.seed
+
+	pace := &pacer{}
+	if s.robots != nil {
+		pace.delay = s.robots.CrawlDelay(ctx, seed)
+	}
+
+	hosts := map[string]bool{seed.Hostname(): true}
+	visited := map[string]bool{seed.String(): true}
+	frontier := []target{{url: seed.String(), depth: 0}}
+	pages := make([]models.CrawlPage, 0, plan.maxPages)
+
+	for len(frontier) > 0 && len(pages) < plan.maxPages && ctx.Err() == nil {
+		if budget := plan.maxPages - len(pages); len(frontier) > budget {
+			frontier = frontier[:budget]
+		}
+
+		level := s.analyzeLevel(ctx, frontier, pace)
+		pages = append(pages, level...)
+
+		// Pages are known by where they were served from, so links to a redirect target aren't crawled twice
+		for _, page := range level {
+			if page.Result == nil || page.Result.Response == nil {
+				continue
+			}
+			if final, ok := normalizeURL(page.Result.Response.FinalURL); ok {
+				visited[final.String()] = true
+				if page.Depth == 0 {
+					hosts[final.Hostname()] = true
+				}
+			}
+		}
+
+		var next []target
+		for _, page := range level {
+			if page.Result == nil || page.Result.Links == nil || page.Depth >= plan.maxDepth {
+				continue
+			}
+			for _, link := range page.Result.Links.InternalURLs {
+				u, ok := normalizeURL(link)
+				if !ok || !hosts[u.Hostname()] || visited[u.String()] {
+					continue
+				}
+				visited[u.String()] = true
+
+				if !pathAllowed(u.Path, plan.include, plan.exclude) {
+					continue
+				}
+				next = append(next, target{url: u.String(), depth: page.Depth + 1})
+			}
+		}
+		frontier = next
+	}
+
+	report := models.CrawlReport{
+		SeedURL: seed.String(),
+		Pages:   pages,
+		Summary: summarize(pages),
+	}
+	report.Summary.PagesDiscovered = len(visited)
+	report.AnalysisTime = int64(time.Since(start) / time.Millisecond)
+
+	return report, nil
+}
+
+// plan validates a crawl request and applies the server limits
+func (s *CrawlerService) plan(req models.CrawlRequest) (crawlPlan, error) {
+	if err := s.urlValidator.ValidateURL(req.URL); err != nil {
+		return crawlPlan{}, err
+	}
+	seed, ok := normalizeURL(req.URL)
+	if !ok {
+		return crawlPlan{}, domainerrors.NewInvalidURLError(req.URL, nil)
+	}
+
+	maxDepth, err := limit("max_depth", req.MaxDepth, s.maxDepth)
+	if err != nil {
+		return crawlPlan{}, err
+	}
+	maxPages, err := limit("max_pages", req.MaxPages, s.maxPages)
+	if err != nil {
+		return crawlPlan{}, err
+	}
+	include, err := compilePatterns("include", req.Include)
+	if err != nil {
+		return crawlPlan{}, err
+	}
+	exclude, err := compilePatterns("exclude", req.Exclude)
+	if err != nil {
+		return crawlPlan{}, err
+	}
+
+	return crawlPlan{seed: seed, maxDepth: maxDepth, maxPages: maxPages, include: include, exclude: exclude}, nil
+}
+
+// analyzeLevel analyzes one breadth-first level with bounded concurrency
+func (s *CrawlerService) analyzeLevel(ctx context.Context, targets []target, pace *pacer) []models.CrawlPage {
+	pages := make([]models.CrawlPage, len(targets))
+
+	sem := make(chan struct{}, s.concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, t := range targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, t target) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			page := models.CrawlPage{URL: t.url, Depth: t.depth}
+			var result models.AnalysisResponse
+			err := pace.wait(ctx)
+			if err != nil {
+				err = domainerrors.NewNetworkTimeoutError(t.url, err)
+			} else {
+				result, err = s.analyzer.Analyze(ctx, t.url)
+			}
+			if err != nil {
+				page.Error = s.mapper.MapToHTTPError(err)
+				page.Error.Timestamp = time.Now()
+			} else {
+				page.Result = &result
+			}
+			pages[i] = page
+		}(i, t)
+	}
+	wg.Wait()
+
+	return pages
+}
+
+// wait blocks until the next fetch slot; slots are spaced by the crawl delay
+func (p *pacer) wait(ctx context.Context) error {
+	if p.delay <= 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.delay)
+	p.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// summarize aggregates per-page results into site-level figures
+func summarize(pages []models.CrawlPage) models.CrawlSummary {
+	summary := models.CrawlSummary{
+		HTMLVersions: map[string]int{},
+	}
+
+	var totalTime int64
+	for _, page := range pages {
+		if page.Depth > summary.MaxDepthReached {
+			summary.MaxDepthReached = page.Depth
+		}
+		if page.Result == nil {
+			summary.PagesFailed++
+			continue
+		}
+
+		r := page.Result
+		summary.PagesAnalyzed++
+		totalTime += r.AnalysisTime
+
+		if r.HasLoginForm != nil && *r.HasLoginForm {
+			summary.PagesWithLoginForm++
+		}
+		if r.PageTitle == nil || *r.PageTitle == "" {
+			summary.PagesWithoutTitle++
+		}
+		if r.HTMLVersion != nil && *r.HTMLVersion != "" {
+			summary.HTMLVersions[*r.HTMLVersion]++
+		}
+
+		if r.Headings != nil {
+			summary.Headings.H1 += r.Headings.H1
+			summary.Headings.H2 += r.Headings.H2
+			summary.Headings.H3 += r.Headings.H3
+			summary.Headings.H4 += r.Headings.H4
+			summary.Headings.H5 += r.Headings.H5
+			summary.Headings.H6 += r.Headings.H6
+		}
+		if r.Links != nil {
+			summary.Links.Internal += r.Links.Internal
+			summary.Links.SameSite += r.Links.SameSite
+			summary.Links.External += r.Links.External
+			summary.Links.Inaccessible += r.Links.Inaccessible
+			summary.Links.Unchecked += r.Links.Unchecked
+			addLinkStats(&summary.Links.ExternalStats, r.Links.ExternalStats)
+			if r.Links.InternalStats != nil {
+				if summary.Links.InternalStats == nil {
+					summary.Links.InternalStats = &models.LinkStats{}
+				}
+				addLinkStats(summary.Links.InternalStats, *r.Links.InternalStats)
+			}
+		}
+	}
+
+	if summary.PagesAnalyzed > 0 {
+		summary.AvgAnalysisTime = totalTime / int64(summary.PagesAnalyzed)
+	}
+	return summary
+}
+
+// Helper functions for crawler
+
+// addLinkStats adds the link check outcomes of one page to the summary's
+func addLinkStats(total *models.LinkStats, page models.LinkStats) {
+	total.OK += page.OK
+	total.Broken += page.Broken
+	total.Unchecked += page.Unchecked
+	total.Disallowed += page.Disallowed
+	total.Redirected += page.Redirected
+}
+
+// limit applies a server-side default and upper bound to a requested limit
+func limit(field string, requested, max int) (int, error) {
+	if requested < 0 {
+		return 0, domainerrors.NewInvalidInputError(field, requested, "must not be negative")
+	}
+	if requested == 0 || requested > max {
+		return max, nil
+	}
+	return requested, nil
+}
+
+// normalizeURL strips fragments and canonicalizes scheme, host and empty paths
+// so the same page is only visited once
+func normalizeURL(rawURL string) (*url.URL, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	return u, true
+}
+
+// compilePatterns turns path glob patterns into anchored regular expressions
+// A "*" matches any sequence of characters, including "/"
+func compilePatterns(field string, patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "*") {
+			return nil, domainerrors.NewInvalidInputError(field, pattern, "path patterns must start with \"/\" or \"*\"")
+		}
+
+		expr := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, domainerrors.NewInvalidInputError(field, pattern, fmt.Sprintf("invalid pattern: %v", err))
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// pathAllowed applies include patterns (any must match, if given) and exclude patterns (none may match)
+func pathAllowed(path string, include, exclude []*regexp.Regexp) bool {
+	for _, re := range exclude {
+		if re.MatchString(path) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, re := range include {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
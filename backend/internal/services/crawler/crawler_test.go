@@ -0,0 +1,177 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSite serves a small site: / → /a, /b, /private/x; /a → /c, /#top; /c → /d
+func newTestSite(t *testing.T) *httptest.Server {
+	pages := map[string]string{
+		"/":          `<a href="/a">A</a><a href="b">B</a><a href="/private/x">X</a><h1>Home</h1>`,
+		"/a":         `<a href="/c">C</a><a href="/#top">Top</a><form><input type="password"></form>`,
+		"/b":         `<a href="/missing">Missing</a>`,
+		"/c":         `<a href="/d">D</a>`,
+		"/d":         `<p>Deep</p>`,
+		"/private/x": `<p>Private</p>`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `<!DOCTYPE html><html><head><title>Page %s</title></head><body>%s</body></html>`, r.URL.Path, body)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestCrawler(t *testing.T, crawlCfg config.CrawlConfig) *CrawlerService {
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 10,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+		Crawl: crawlCfg,
+	}
+
+	analyzerService, err := analyzer.NewAnalyzerService(cfg,
+		analyzer.WithExtractors(
+			&extractors.TitleExtractor{},
+			&extractors.HeadingsExtractor{},
+			&extractors.LinksExtractor{},
+			&extractors.LoginFormExtractor{},
+			&extractors.VersionExtractor{},
+		))
+	require.NoError(t, err)
+
+	return NewCrawlerService(cfg, analyzerService, nil)
+}
+
+func pageURLs(pages []models.CrawlPage) []string {
+	urls := make([]string, len(pages))
+	for i, p := range pages {
+		urls[i] = p.URL
+	}
+	return urls
+}
+
+func TestCrawlerService_BreadthFirstWithLimits(t *testing.T) {
+	ts := newTestSite(t)
+	crawler := newTestCrawler(t, config.CrawlConfig{Concurrency: 2})
+
+	report, err := crawler.Crawl(context.Background(), models.CrawlRequest{
+		URL:      ts.URL,
+		MaxDepth: 2,
+		Exclude:  []string{"/private/*"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		ts.URL + "/",
+		ts.URL + "/a",
+		ts.URL + "/b",
+		ts.URL + "/c",
+		ts.URL + "/missing",
+	}, pageURLs(report.Pages))
+
+	summary := report.Summary
+	assert.Equal(t, 4, summary.PagesAnalyzed)
+	assert.Equal(t, 1, summary.PagesFailed)
+	assert.Equal(t, 2, summary.MaxDepthReached)
+	assert.Equal(t, 1, summary.PagesWithLoginForm)
+	assert.Equal(t, 1, summary.Headings.H1)
+	assert.Equal(t, 4, summary.HTMLVersions["HTML5"])
+	// Seed, /a, /b, /private/x, /c and /missing; /c sits at max depth so /d is never discovered
+	assert.Equal(t, 6, summary.PagesDiscovered)
+
+	require.NotNil(t, report.Pages[4].Error)
+	assert.Equal(t, "HTTP_NOT_FOUND", report.Pages[4].Error.Type)
+}
+
+func TestCrawlerService_MaxPagesAndInclude(t *testing.T) {
+	ts := newTestSite(t)
+	crawler := newTestCrawler(t, config.CrawlConfig{MaxPages: 2})
+
+	report, err := crawler.Crawl(context.Background(), models.CrawlRequest{URL: ts.URL, MaxPages: 10})
+	require.NoError(t, err)
+	assert.Len(t, report.Pages, 2, "max_pages is capped by the server configuration")
+
+	crawler = newTestCrawler(t, config.CrawlConfig{})
+	report, err = crawler.Crawl(context.Background(), models.CrawlRequest{URL: ts.URL, Include: []string{"/private/*"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ts.URL + "/", ts.URL + "/private/x"}, pageURLs(report.Pages))
+}
+
+func TestCrawlerService_FollowsSeedRedirect(t *testing.T) {
+	// The seed host redirects to another host name of the same server, like an apex to www redirect
+	var canonical string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != canonical {
+			http.Redirect(w, r, "http://"+canonical+r.URL.Path, http.StatusMovedPermanently)
+			return
+		}
+		links := map[string]string{
+			"/":  `<a href="/a">A</a><a href="b">B</a>`,
+			"/a": `<a href="/">Home</a>`,
+			"/b": `<p>B</p>`,
+		}
+		fmt.Fprintf(w, `<!DOCTYPE html><html><body>%s</body></html>`, links[r.URL.Path])
+	}))
+	t.Cleanup(ts.Close)
+	canonical = "localhost:" + ts.URL[strings.LastIndex(ts.URL, ":")+1:]
+
+	crawler := newTestCrawler(t, config.CrawlConfig{})
+	report, err := crawler.Crawl(context.Background(), models.CrawlRequest{URL: ts.URL})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		ts.URL + "/",
+		"http://" + canonical + "/a",
+		"http://" + canonical + "/b",
+	}, pageURLs(report.Pages), "links are resolved against and filtered by the redirect target")
+	assert.Zero(t, report.Summary.PagesFailed)
+}
+
+func TestCrawlerService_InvalidRequest(t *testing.T) {
+	crawler := newTestCrawler(t, config.CrawlConfig{})
+
+	_, err := crawler.Crawl(context.Background(), models.CrawlRequest{URL: "ftp://example.com"})
+	assert.Error(t, err)
+
+	_, err = crawler.Crawl(context.Background(), models.CrawlRequest{URL: "https://example.com", MaxDepth: -1})
+	assert.Error(t, err)
+
+	_, err = crawler.Crawl(context.Background(), models.CrawlRequest{URL: "https://example.com", Include: []string{"blog"}})
+	assert.Error(t, err)
+}
+
+func TestPathAllowed(t *testing.T) {
+	include, err := compilePatterns("include", []string{"/blog/*", "/about"})
+	require.NoError(t, err)
+	exclude, err := compilePatterns("exclude", []string{"*/drafts/*"})
+	require.NoError(t, err)
+
+	assert.True(t, pathAllowed("/blog/2024/post", include, exclude))
+	assert.True(t, pathAllowed("/about", include, exclude))
+	assert.False(t, pathAllowed("/about/team", include, exclude))
+	assert.False(t, pathAllowed("/blog/drafts/post", include, exclude))
+	assert.True(t, pathAllowed("/anything", nil, nil))
+}
@@ -6,8 +6,13 @@ import (
 	"github.com/steve-phan/page-insight-tool/internal/config"
 	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
 	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+	"github.com/steve-phan/page-insight-tool/internal/services/batch"
+	"github.com/steve-phan/page-insight-tool/internal/services/crawler"
 	"github.com/steve-phan/page-insight-tool/internal/services/health"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
+	"github.com/steve-phan/page-insight-tool/internal/services/linkcheck"
 	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 )
 
 // ServiceFactory handles creation and validation of all application services
@@ -31,27 +36,65 @@ func (sf *ServiceFactory) CreateServices() (*Services, error) {
 		return nil, fmt.Errorf("failed to create Redis service: %w", err)
 	}
 
+	// Create robots.txt service; it always backs the robots endpoint but is
+	// only enforced on outbound fetches when enabled
+	robotsService := robots.NewRobotsService(sf.config)
+	var enforcedRobots *robots.RobotsService
+	if sf.config.Robots.Enabled {
+		enforcedRobots = robotsService
+	}
+
+	// Link check results are shared across analyses through Redis when enabled
+	linkCheckOptions := []linkcheck.Option{linkcheck.WithRobots(enforcedRobots)}
+	if sf.config.LinkCheck.CacheEnabled {
+		linkCheckOptions = append(linkCheckOptions, linkcheck.WithCache(redisService))
+	}
+
 	// Create analyzer service with configured extractors
 	analyzerService, err := analyzer.NewAnalyzerService(sf.config,
+		analyzer.WithRobots(enforcedRobots),
 		analyzer.WithExtractors(
 			&extractors.TitleExtractor{},
 			&extractors.HeadingsExtractor{},
-			&extractors.LinksExtractor{},
+			&extractors.LinksExtractor{
+				Checker:         linkcheck.NewChecker(sf.config, linkCheckOptions...),
+				CheckInternal:   sf.config.LinkCheck.CheckInternal,
+				FirstPartyHosts: sf.config.Analysis.FirstPartyHosts,
+			},
 			&extractors.LoginFormExtractor{},
+			&extractors.AccessibilityExtractor{},
 			&extractors.VersionExtractor{},
+			&extractors.CSRExtractor{},
+			&extractors.MetaExtractor{},
+			&extractors.StructuredDataExtractor{},
 		))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create analyzer service: %w", err)
 	}
 
+	// Create batch service on top of the analyzer
+	batchService := batch.NewBatchService(sf.config, analyzerService)
+
+	// Create crawler service following internal links
+	crawlerService := crawler.NewCrawlerService(sf.config, analyzerService, enforcedRobots)
+
+	// Create job service using Redis as queue and result store
+	jobService := jobs.NewJobService(sf.config, redisService, analyzerService,
+		jobs.WithBatch(batchService),
+		jobs.WithCrawler(crawlerService))
+
 	// Create health service
 	healthService := health.NewHealthService(sf.config)
 
 	return &Services{
 		Config:   sf.config,
 		Analyzer: analyzerService,
+		Batch:    batchService,
+		Crawler:  crawlerService,
 		Health:   healthService,
+		Jobs:     jobService,
 		Redis:    redisService,
+		Robots:   robotsService,
 	}, nil
 }
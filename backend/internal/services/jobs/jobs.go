@@ -0,0 +1,511 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/batch"
+	"github.com/steve-phan/page-insight-tool/internal/services/crawler"
+	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/validation"
+)
+
+const (
+	defaultWorkers   = 4
+	defaultResultTTL = time.Hour
+	defaultQueueTTL  = 24 * time.Hour
+
+	keyPrefix = "jobs"
+
+	// pollTimeout bounds each BLMOVE so idle workers notice Stop promptly
+	pollTimeout = time.Second
+	// cancelCheckInterval is how often a running job checks whether it was deleted
+	cancelCheckInterval = time.Second
+	// retryDelay is how long a worker backs off after a Redis error
+	retryDelay = time.Second
+
+	// leaseTTL is how long an instance's jobs stay claimed after its last heartbeat
+	leaseTTL = 30 * time.Second
+	// leaseRefresh is how often a running instance renews its lease
+	leaseRefresh = 10 * time.Second
+	// reapInterval is how often instances look for jobs of instances whose lease expired
+	reapInterval = leaseTTL
+)
+
+// Option configures a job service
+type Option func(*JobService)
+
+// WithBatch lets the service run batch jobs through the given batch service
+func WithBatch(batchService *batch.BatchService) Option {
+	return func(s *JobService) {
+		s.batch = batchService
+	}
+}
+
+// WithCrawler lets the service run crawl jobs through the given crawler service
+func WithCrawler(crawlerService *crawler.CrawlerService) Option {
+	return func(s *JobService) {
+		s.crawler = crawlerService
+	}
+}
+
+// JobService runs analyses asynchronously using Redis as queue and result store
+// Jobs are stored as JSON under jobs:job:<id> and their IDs are queued in jobs:queue
+// Each instance moves the IDs it runs to its own jobs:processing:<instance> list and keeps
+// a jobs:lease:<instance> key alive while it runs. Once an instance's lease expires, e.g.
+// because it crashed, any instance queues its leftover jobs again; a job may then run
+// twice, but is never lost
+type JobService struct {
+	redis        *goredis.Client
+	analyzer     *analyzer.AnalyzerService
+	batch        *batch.BatchService
+	crawler      *crawler.CrawlerService
+	urlValidator *validation.URLValidator
+	mapper       *domainerrors.ErrorMapper
+	workers      int
+	ttl          time.Duration
+	queueTTL     time.Duration
+	instance     string
+
+	stop chan struct{}
+	// shutdown is the parent context of running jobs, cancelled by Stop
+	shutdown context.Context
+	cancel   context.CancelFunc
+
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewJobService creates a new job service backed by the given Redis service
+func NewJobService(cfg *config.Config, redisService *redis.RedisService, analyzerService *analyzer.AnalyzerService, options ...Option) *JobService {
+	workers := cfg.Jobs.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	ttl := cfg.Jobs.ResultTTL
+	if ttl <= 0 {
+		ttl = defaultResultTTL
+	}
+	queueTTL := cfg.Jobs.QueueTTL
+	if queueTTL <= 0 {
+		queueTTL = defaultQueueTTL
+	}
+
+	service := &JobService{
+		redis:        redisService.GetClient(),
+		analyzer:     analyzerService,
+		urlValidator: validation.NewURLValidator(),
+		mapper:       domainerrors.NewErrorMapper(),
+		workers:      workers,
+		ttl:          ttl,
+		queueTTL:     queueTTL,
+		instance:     uuid.New().String(),
+		stop:         make(chan struct{}),
+	}
+	service.shutdown, service.cancel = context.WithCancel(context.Background())
+	for _, option := range options {
+		option(service)
+	}
+	return service
+}
+
+// Create validates the URL and enqueues a new analysis job
+func (s *JobService) Create(ctx context.Context, rawURL string) (models.Job, error) {
+	if err := s.urlValidator.ValidateURL(rawURL); err != nil {
+		return models.Job{}, err
+	}
+
+	return s.enqueue(ctx, models.Job{Type: models.JobTypeAnalyze, URL: rawURL})
+}
+
+// CreateBatch checks the size of the URL list and enqueues a batch job
+// Individual URLs are validated when the batch runs, so one bad URL doesn't reject the others
+func (s *JobService) CreateBatch(ctx context.Context, urls []string) (models.Job, error) {
+	if s.batch == nil {
+		return models.Job{}, domainerrors.NewInternalError("batch jobs are not configured", nil)
+	}
+	if err := s.batch.Validate(urls); err != nil {
+		return models.Job{}, err
+	}
+
+	return s.enqueue(ctx, models.Job{Type: models.JobTypeBatch, URLs: urls})
+}
+
+// CreateCrawl validates the crawl request and enqueues a crawl job
+func (s *JobService) CreateCrawl(ctx context.Context, req models.CrawlRequest) (models.Job, error) {
+	if s.crawler == nil {
+		return models.Job{}, domainerrors.NewInternalError("crawl jobs are not configured", nil)
+	}
+	if err := s.crawler.Validate(req); err != nil {
+		return models.Job{}, err
+	}
+
+	return s.enqueue(ctx, models.Job{Type: models.JobTypeCrawl, URL: req.URL, Crawl: &req})
+}
+
+// enqueue stores a new job as queued and appends it to the queue
+func (s *JobService) enqueue(ctx context.Context, job models.Job) (models.Job, error) {
+	now := time.Now().UTC()
+	job.ID = uuid.New().String()
+	job.Status = models.JobStatusQueued
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return models.Job{}, domainerrors.NewInternalError("failed to encode job", err)
+	}
+
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, jobKey(job.ID), data, s.queueTTL)
+	pipe.RPush(ctx, queueKey(), job.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return models.Job{}, domainerrors.NewInternalError("failed to enqueue job", err)
+	}
+
+	return job, nil
+}
+
+// Get returns the current state of a job
+func (s *JobService) Get(ctx context.Context, id string) (models.Job, error) {
+	data, err := s.redis.Get(ctx, jobKey(id)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return models.Job{}, domainerrors.NewNotFoundError("job", id)
+	}
+	if err != nil {
+		return models.Job{}, domainerrors.NewInternalError("failed to load job", err)
+	}
+
+	var job models.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return models.Job{}, domainerrors.NewInternalError("failed to decode job", err)
+	}
+	return job, nil
+}
+
+// Delete removes a job; a queued job is dropped and a running job is cancelled
+func (s *JobService) Delete(ctx context.Context, id string) error {
+	pipe := s.redis.TxPipeline()
+	del := pipe.Del(ctx, jobKey(id))
+	pipe.LRem(ctx, queueKey(), 0, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return domainerrors.NewInternalError("failed to delete job", err)
+	}
+
+	if del.Val() == 0 {
+		return domainerrors.NewNotFoundError("job", id)
+	}
+	return nil
+}
+
+// Start takes the instance's lease, launches the background workers and queues the
+// jobs left over by instances whose lease expired
+// Calling it more than once is a no-op
+func (s *JobService) Start() {
+	s.startOnce.Do(func() {
+		s.renewLease(context.Background())
+		if err := s.redis.SAdd(context.Background(), instancesKey(), s.instance).Err(); err != nil {
+			log.Printf("[WARN] job worker: failed to register instance: %v", err)
+		}
+
+		s.wg.Add(2)
+		go s.heartbeat()
+		go s.reaper()
+		for i := 0; i < s.workers; i++ {
+			s.wg.Add(1)
+			go s.worker()
+		}
+	})
+}
+
+// Stop signals the workers to exit, cancels the running jobs and releases the lease
+// Cancelled jobs stay in the processing list and are run again by the next instance that reaps
+func (s *JobService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		s.cancel()
+	})
+	s.wg.Wait()
+
+	// Jobs still in the processing list are requeued by the next instance that reaps
+	ctx := context.Background()
+	s.redis.Del(ctx, leaseKey(s.instance))
+	if n, err := s.redis.LLen(ctx, processingKey(s.instance)).Result(); err == nil && n == 0 {
+		s.redis.SRem(ctx, instancesKey(), s.instance)
+	}
+}
+
+// renewLease extends the instance's claim on the jobs in its processing list
+func (s *JobService) renewLease(ctx context.Context) {
+	if err := s.redis.Set(ctx, leaseKey(s.instance), 1, leaseTTL).Err(); err != nil {
+		log.Printf("[WARN] job worker: failed to renew lease: %v", err)
+	}
+}
+
+// heartbeat renews the instance's lease until Stop is called
+func (s *JobService) heartbeat() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(leaseRefresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.renewLease(context.Background())
+		}
+	}
+}
+
+// reaper requeues the jobs of expired instances right away and then every reapInterval until Stop is called
+func (s *JobService) reaper() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for {
+		s.reap(context.Background())
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reap requeues the jobs of every registered instance whose lease expired
+// A lock keeps instances from reaping at the same time
+func (s *JobService) reap(ctx context.Context) {
+	locked, err := s.redis.SetNX(ctx, reaperKey(), s.instance, leaseTTL).Result()
+	if err != nil || !locked {
+		return
+	}
+	defer s.redis.Del(ctx, reaperKey())
+
+	instances, err := s.redis.SMembers(ctx, instancesKey()).Result()
+	if err != nil {
+		log.Printf("[WARN] job worker: failed to list instances: %v", err)
+		return
+	}
+	for _, instance := range instances {
+		alive, err := s.redis.Exists(ctx, leaseKey(instance)).Result()
+		if err != nil || alive > 0 {
+			continue
+		}
+		if s.requeue(ctx, instance) {
+			s.redis.SRem(ctx, instancesKey(), instance)
+		}
+	}
+}
+
+// requeue moves the jobs left in an expired instance's processing list back to the front of the queue
+// and reports whether the list is empty; finished jobs are only dropped from the list
+func (s *JobService) requeue(ctx context.Context, instance string) bool {
+	for {
+		id, err := s.redis.LIndex(ctx, processingKey(instance), -1).Result()
+		if errors.Is(err, goredis.Nil) {
+			return true
+		}
+		if err != nil {
+			log.Printf("[WARN] job worker: failed to requeue interrupted jobs: %v", err)
+			return false
+		}
+
+		// The status is reset before the ID is queued, so no worker sees a queued ID of a running job
+		job, err := s.Get(ctx, id)
+		requeue := err == nil && (job.Status == models.JobStatusQueued || job.Status == models.JobStatusRunning)
+		if requeue {
+			job.Status = models.JobStatusQueued
+			job.UpdatedAt = time.Now().UTC()
+			s.save(ctx, job, s.queueTTL)
+		}
+
+		pipe := s.redis.TxPipeline()
+		pipe.RPop(ctx, processingKey(instance))
+		if requeue {
+			pipe.LPush(ctx, queueKey(), id)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("[WARN] job worker: failed to requeue interrupted jobs: %v", err)
+			return false
+		}
+		if requeue {
+			log.Printf("[INFO] job %s: requeued after an interrupted run", id)
+		}
+	}
+}
+
+// worker moves job IDs from the queue to the processing list until Stop is called
+func (s *JobService) worker() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		id, err := s.redis.BLMove(context.Background(), queueKey(), processingKey(s.instance), "LEFT", "RIGHT", pollTimeout).Result()
+		if errors.Is(err, goredis.Nil) {
+			continue // Poll timed out, queue is empty
+		}
+		if err != nil {
+			log.Printf("[WARN] job worker: failed to poll queue: %v", err)
+			select {
+			case <-s.stop:
+				return
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+
+		if !s.process(id) {
+			return // Interrupted by Stop, left in the processing list
+		}
+		if err := s.redis.LRem(context.Background(), processingKey(s.instance), 1, id).Err(); err != nil {
+			log.Printf("[WARN] job %s: failed to remove job from the processing list: %v", id, err)
+		}
+	}
+}
+
+// process runs a single job and stores its outcome
+// It reports false if Stop interrupted the job, which then keeps its running status
+func (s *JobService) process(id string) bool {
+	ctx := context.Background()
+
+	job, err := s.Get(ctx, id)
+	if err != nil || job.Status != models.JobStatusQueued {
+		return true // Deleted while queued, unreadable, or already run
+	}
+
+	job.Status = models.JobStatusRunning
+	job.UpdatedAt = time.Now().UTC()
+	if !s.save(ctx, job, s.queueTTL) {
+		return true
+	}
+
+	runCtx, cancel := context.WithCancel(s.shutdown)
+	defer cancel()
+	go s.cancelOnDelete(runCtx, id, cancel)
+
+	err = s.run(runCtx, &job)
+	if s.shutdown.Err() != nil {
+		return false
+	}
+	if err != nil {
+		job.Status = models.JobStatusFailed
+		job.Error = s.mapper.MapToHTTPError(err)
+		job.Error.Timestamp = time.Now()
+	} else {
+		job.Status = models.JobStatusDone
+	}
+	job.UpdatedAt = time.Now().UTC()
+
+	s.save(ctx, job, s.ttl)
+	return true
+}
+
+// run does the work of a job and stores the outcome on it
+func (s *JobService) run(ctx context.Context, job *models.Job) error {
+	switch job.Type {
+	case models.JobTypeBatch:
+		if s.batch == nil {
+			return domainerrors.NewInternalError("batch jobs are not configured", nil)
+		}
+		result, err := s.batch.Analyze(ctx, job.URLs)
+		if err != nil {
+			return err
+		}
+		job.BatchResult = &result
+	case models.JobTypeCrawl:
+		if s.crawler == nil || job.Crawl == nil {
+			return domainerrors.NewInternalError("crawl jobs are not configured", nil)
+		}
+		report, err := s.crawler.Crawl(ctx, *job.Crawl)
+		if err != nil {
+			return err
+		}
+		job.CrawlResult = &report
+	default:
+		result, err := s.analyzer.Analyze(ctx, job.URL)
+		if err != nil {
+			return err
+		}
+		job.Result = &result
+	}
+	return nil
+}
+
+// save overwrites an existing job record and reports whether it still existed
+// A job deleted while running is never resurrected
+func (s *JobService) save(ctx context.Context, job models.Job, ttl time.Duration) bool {
+	data, err := json.Marshal(job)
+	if err != nil {
+		log.Printf("[ERROR] job %s: failed to encode job: %v", job.ID, err)
+		return false
+	}
+
+	ok, err := s.redis.SetXX(ctx, jobKey(job.ID), data, ttl).Result()
+	if err != nil {
+		log.Printf("[ERROR] job %s: failed to save job: %v", job.ID, err)
+		return false
+	}
+	return ok
+}
+
+// cancelOnDelete cancels a running job once its record disappears
+// Polling Redis makes DELETE work regardless of which instance runs the job
+func (s *JobService) cancelOnDelete(ctx context.Context, id string, cancel context.CancelFunc) {
+	ticker := time.NewTicker(cancelCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if n, err := s.redis.Exists(ctx, jobKey(id)).Result(); err == nil && n == 0 {
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func jobKey(id string) string {
+	return keyPrefix + ":job:" + id
+}
+
+func queueKey() string {
+	return keyPrefix + ":queue"
+}
+
+func processingKey(instance string) string {
+	return keyPrefix + ":processing:" + instance
+}
+
+func leaseKey(instance string) string {
+	return keyPrefix + ":lease:" + instance
+}
+
+func instancesKey() string {
+	return keyPrefix + ":instances"
+}
+
+func reaperKey() string {
+	return keyPrefix + ":reaper"
+}
@@ -0,0 +1,327 @@
+package jobs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+	"github.com/steve-phan/page-insight-tool/internal/services/batch"
+	"github.com/steve-phan/page-insight-tool/internal/services/crawler"
+	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+)
+
+func newTestJobService(t *testing.T) (*JobService, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	port, err := strconv.Atoi(mr.Port())
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		Analysis: config.AnalysisConfig{
+			Timeout:     30,
+			MaxBodySize: 10,
+		},
+		App: config.AppConfig{
+			Name: "Test App",
+		},
+		Redis: config.RedisConfig{
+			Host:     mr.Host(),
+			Port:     port,
+			PoolSize: 10,
+		},
+		Jobs: config.JobsConfig{
+			Workers:   1,
+			ResultTTL: time.Minute,
+			QueueTTL:  time.Hour,
+		},
+	}
+
+	redisService, err := redis.NewRedisService(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { redisService.Close() })
+
+	analyzerService, err := analyzer.NewAnalyzerService(cfg,
+		analyzer.WithExtractors(&extractors.TitleExtractor{}))
+	require.NoError(t, err)
+
+	return NewJobService(cfg, redisService, analyzerService,
+		WithBatch(batch.NewBatchService(cfg, analyzerService)),
+		WithCrawler(crawler.NewCrawlerService(cfg, analyzerService, nil))), mr
+}
+
+func waitForStatus(t *testing.T, service *JobService, id string, statuses ...models.JobStatus) models.Job {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := service.Get(context.Background(), id)
+		require.NoError(t, err)
+		for _, status := range statuses {
+			if job.Status == status {
+				return job
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach status %v", id, statuses)
+	return models.Job{}
+}
+
+func TestJobService_Lifecycle(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Async Page</title></head></html>`))
+	}))
+	defer ts.Close()
+
+	service, mr := newTestJobService(t)
+
+	job, err := service.Create(context.Background(), ts.URL)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, job.Status)
+	assert.NotEmpty(t, job.ID)
+
+	// Queued jobs are visible before any worker runs
+	queued, err := service.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, queued.Status)
+
+	service.Start()
+	defer service.Stop()
+
+	done := waitForStatus(t, service, job.ID, models.JobStatusDone, models.JobStatusFailed)
+	require.Equal(t, models.JobStatusDone, done.Status)
+	require.NotNil(t, done.Result)
+	require.NotNil(t, done.Result.PageTitle)
+	assert.Equal(t, "Async Page", *done.Result.PageTitle)
+	assert.Nil(t, done.Error)
+
+	// Finished jobs expire after the result TTL
+	mr.FastForward(2 * time.Minute)
+	_, err = service.Get(context.Background(), job.ID)
+	assert.Error(t, err)
+}
+
+func TestJobService_QueuedJobsOutliveResultTTL(t *testing.T) {
+	service, mr := newTestJobService(t)
+
+	job, err := service.Create(context.Background(), "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, mr.TTL(jobKey(job.ID)))
+
+	// A job waiting longer than the result TTL is still queued
+	mr.FastForward(2 * time.Minute)
+	queued, err := service.Get(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, queued.Status)
+}
+
+func TestJobService_RequeuesJobsOfExpiredInstances(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Recovered Page</title></head></html>`))
+	}))
+	defer ts.Close()
+
+	service, mr := newTestJobService(t)
+	ctx := context.Background()
+
+	// claim moves a queued job into another instance's processing list with the given status
+	claim := func(instance string, status models.JobStatus) models.Job {
+		job, err := service.Create(ctx, ts.URL)
+		require.NoError(t, err)
+		_, err = mr.Lpop(queueKey())
+		require.NoError(t, err)
+		_, err = mr.Push(processingKey(instance), job.ID)
+		require.NoError(t, err)
+		_, err = mr.SAdd(instancesKey(), instance)
+		require.NoError(t, err)
+		job.Status = status
+		require.True(t, service.save(ctx, job, time.Hour))
+		return job
+	}
+
+	// An instance that crashed mid-run, after a job finished but before it left the list
+	interrupted := claim("crashed", models.JobStatusRunning)
+	finished := claim("crashed", models.JobStatusDone)
+	// A live instance, e.g. the old one during a rolling deploy, still holds its lease
+	running := claim("live", models.JobStatusRunning)
+	require.NoError(t, mr.Set(leaseKey("live"), "1"))
+	mr.SetTTL(leaseKey("live"), time.Minute)
+
+	service.Start()
+	defer service.Stop()
+
+	done := waitForStatus(t, service, interrupted.ID, models.JobStatusDone, models.JobStatusFailed)
+	require.Equal(t, models.JobStatusDone, done.Status)
+	assert.Equal(t, "Recovered Page", *done.Result.PageTitle)
+
+	// The finished job isn't run again and the expired instance is forgotten
+	again, err := service.Get(ctx, finished.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusDone, again.Status)
+	assert.Nil(t, again.Result)
+	assert.Eventually(t, func() bool {
+		processing, _ := mr.List(processingKey("crashed"))
+		isMember, _ := mr.SIsMember(instancesKey(), "crashed")
+		return len(processing) == 0 && !isMember
+	}, time.Second, 10*time.Millisecond)
+
+	// Jobs of the live instance are left alone
+	stillRunning, err := service.Get(ctx, running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusRunning, stillRunning.Status)
+	processing, _ := mr.List(processingKey("live"))
+	assert.Equal(t, []string{running.ID}, processing)
+
+	// Once its lease expires, its jobs are requeued by the next reap
+	mr.FastForward(2 * time.Minute)
+	service.reap(ctx)
+	rerun := waitForStatus(t, service, running.ID, models.JobStatusDone, models.JobStatusFailed)
+	assert.Equal(t, models.JobStatusDone, rerun.Status)
+}
+
+func TestJobService_FailedJob(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	service, _ := newTestJobService(t)
+	service.Start()
+	defer service.Stop()
+
+	job, err := service.Create(context.Background(), ts.URL)
+	require.NoError(t, err)
+
+	failed := waitForStatus(t, service, job.ID, models.JobStatusDone, models.JobStatusFailed)
+	require.Equal(t, models.JobStatusFailed, failed.Status)
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, string(domainerrors.ErrorTypeHTTPNotFound), failed.Error.Type)
+}
+
+func TestJobService_Delete(t *testing.T) {
+	service, mr := newTestJobService(t)
+
+	job, err := service.Create(context.Background(), "https://example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, service.Delete(context.Background(), job.ID))
+
+	// Deleted jobs are gone from both the store and the queue
+	_, err = service.Get(context.Background(), job.ID)
+	assert.Error(t, err)
+	queue, _ := mr.List(queueKey())
+	assert.NotContains(t, queue, job.ID)
+
+	// Deleting twice reports not found
+	err = service.Delete(context.Background(), job.ID)
+	var domainErr *domainerrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, domainerrors.ErrorTypeNotFound, domainErr.Type)
+}
+
+func TestJobService_CreateInvalidURL(t *testing.T) {
+	service, _ := newTestJobService(t)
+
+	_, err := service.Create(context.Background(), "ftp://example.com")
+	assert.Error(t, err)
+}
+
+func TestJobService_Batch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Batch Page</title></head></html>`))
+	}))
+	defer ts.Close()
+
+	service, _ := newTestJobService(t)
+
+	_, err := service.CreateBatch(context.Background(), nil)
+	assert.True(t, domainerrors.IsInputValidationError(err), "empty batch: got %v", err)
+
+	job, err := service.CreateBatch(context.Background(), []string{ts.URL, "ftp://example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobTypeBatch, job.Type)
+
+	service.Start()
+	defer service.Stop()
+
+	// One bad URL fails only its own entry
+	done := waitForStatus(t, service, job.ID, models.JobStatusDone, models.JobStatusFailed)
+	require.Equal(t, models.JobStatusDone, done.Status)
+	require.NotNil(t, done.BatchResult)
+	assert.Nil(t, done.Result)
+	assert.Equal(t, 2, done.BatchResult.Total)
+	assert.Equal(t, 1, done.BatchResult.Succeeded)
+	assert.Equal(t, "Batch Page", *done.BatchResult.Results[0].Result.PageTitle)
+	assert.NotNil(t, done.BatchResult.Results[1].Error)
+}
+
+func TestJobService_Crawl(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><head><title>Crawled Page</title></head></html>`))
+	}))
+	defer ts.Close()
+
+	service, _ := newTestJobService(t)
+
+	_, err := service.CreateCrawl(context.Background(), models.CrawlRequest{URL: ts.URL, MaxDepth: -1})
+	assert.True(t, domainerrors.IsInputValidationError(err), "negative depth: got %v", err)
+
+	job, err := service.CreateCrawl(context.Background(), models.CrawlRequest{URL: ts.URL, MaxPages: 5})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobTypeCrawl, job.Type)
+	assert.Equal(t, ts.URL, job.URL)
+
+	service.Start()
+	defer service.Stop()
+
+	done := waitForStatus(t, service, job.ID, models.JobStatusDone, models.JobStatusFailed)
+	require.Equal(t, models.JobStatusDone, done.Status)
+	require.NotNil(t, done.CrawlResult)
+	assert.Nil(t, done.Result)
+	require.Len(t, done.CrawlResult.Pages, 1)
+	assert.Equal(t, "Crawled Page", *done.CrawlResult.Pages[0].Result.PageTitle)
+}
+
+func TestJobService_StopCancelsRunningJobs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // Never answers
+	}))
+	defer ts.Close()
+
+	service, mr := newTestJobService(t)
+	ctx := context.Background()
+
+	job, err := service.Create(ctx, ts.URL)
+	require.NoError(t, err)
+	service.Start()
+	waitForStatus(t, service, job.ID, models.JobStatusRunning)
+
+	start := time.Now()
+	service.Stop()
+	assert.Less(t, time.Since(start), 2*time.Second, "Stop waits for the cancelled jobs only")
+
+	// The interrupted job isn't failed; it stays claimed until its instance is reaped
+	interrupted, err := service.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusRunning, interrupted.Status)
+	processing, _ := mr.List(processingKey(service.instance))
+	assert.Equal(t, []string{job.ID}, processing)
+	assert.False(t, mr.Exists(leaseKey(service.instance)), "a stopped instance releases its lease")
+
+	service.reap(ctx)
+	requeued, err := service.Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusQueued, requeued.Status)
+	queue, _ := mr.List(queueKey())
+	assert.Equal(t, []string{job.ID}, queue)
+}
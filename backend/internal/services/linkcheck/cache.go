@@ -0,0 +1,153 @@
+package linkcheck
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+)
+
+const (
+	defaultCacheTTL        = 24 * time.Hour
+	defaultCacheFailureTTL = 15 * time.Minute
+
+	cacheKeyPrefix = "linkcheck:result:"
+	// cacheTimeout bounds each cache round trip; a slow Redis only costs the cache, never the check
+	cacheTimeout = 500 * time.Millisecond
+)
+
+type recheckKey struct{}
+
+// WithRecheck returns a context whose link checks skip cached results; fresh results are still cached
+func WithRecheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, recheckKey{}, true)
+}
+
+// recheckRequested reports whether the context asks for cached results to be ignored
+func recheckRequested(ctx context.Context) bool {
+	recheck, _ := ctx.Value(recheckKey{}).(bool)
+	return recheck
+}
+
+// cachedResult is the part of a Result stored in Redis
+type cachedResult struct {
+	FinalURL   string  `json:"final_url"`
+	StatusCode int     `json:"status_code"`
+	ErrorType  string  `json:"error_type,omitempty"`
+	Problem    string  `json:"problem,omitempty"`
+	Duration   float64 `json:"duration_ms"`
+}
+
+// resultCache shares link check results across analyses
+// Results are stored as JSON under linkcheck:result:<sha256 of the URL>
+type resultCache struct {
+	redis      *goredis.Client
+	ttl        time.Duration
+	failureTTL time.Duration
+}
+
+// load returns the cached results of the given URLs; lookup failures count as misses
+func (c *resultCache) load(ctx context.Context, urls []string) map[string]Result {
+	results := make(map[string]Result)
+	if len(urls) == 0 {
+		return results
+	}
+
+	keys := make([]string, len(urls))
+	for i, link := range urls {
+		keys[i] = cacheKey(link)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
+	defer cancel()
+	values, err := c.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		log.Printf("link check cache lookup failed: %v", err)
+		return results
+	}
+
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var cached cachedResult
+		if err := json.Unmarshal([]byte(data), &cached); err != nil {
+			continue
+		}
+		results[urls[i]] = cached.result(urls[i])
+	}
+	return results
+}
+
+// store caches the outcome of finished checks; unchecked and disallowed links say nothing about the link and are skipped
+func (c *resultCache) store(ctx context.Context, results []Result) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cacheTimeout)
+	defer cancel()
+
+	pipe := c.redis.Pipeline()
+	queued := 0
+	for _, result := range results {
+		if result.Unchecked || result.Disallowed || result.Cached {
+			continue
+		}
+
+		cached := cachedResult{
+			FinalURL:   result.FinalURL,
+			StatusCode: result.StatusCode,
+			ErrorType:  result.ErrorType(),
+			Problem:    result.Problem(),
+			Duration:   float64(result.Duration.Microseconds()) / 1000,
+		}
+		data, err := json.Marshal(cached)
+		if err != nil {
+			continue
+		}
+
+		ttl := c.ttl
+		if !result.Reachable() {
+			ttl = c.failureTTL
+		}
+		pipe.Set(ctx, cacheKey(result.URL), data, ttl)
+		queued++
+	}
+	if queued == 0 {
+		return
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("link check cache update failed: %v", err)
+	}
+}
+
+// result restores a cached check as a Result
+func (c cachedResult) result(link string) Result {
+	result := Result{
+		URL:        link,
+		FinalURL:   c.FinalURL,
+		StatusCode: c.StatusCode,
+		Duration:   time.Duration(c.Duration * float64(time.Millisecond)),
+		Cached:     true,
+	}
+	if c.ErrorType != "" {
+		result.Err = &domainerrors.DomainError{
+			Type:    domainerrors.ErrorType(c.ErrorType),
+			Message: "cached link check failed: " + link,
+			Cause:   errors.New(c.Problem),
+		}
+	}
+	return result
+}
+
+// cacheKey hashes the URL so arbitrarily long links make bounded keys
+func cacheKey(link string) string {
+	sum := sha256.Sum256([]byte(link))
+	return cacheKeyPrefix + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+)
+
+func newCachedTestChecker(t *testing.T) (*Checker, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	port, err := strconv.Atoi(mr.Port())
+	require.NoError(t, err)
+
+	cfg := &config.Config{
+		App:   config.AppConfig{Name: "Test App"},
+		Redis: config.RedisConfig{Host: mr.Host(), Port: port, PoolSize: 10},
+		LinkCheck: config.LinkCheckConfig{
+			CacheTTL:        time.Hour,
+			CacheFailureTTL: time.Minute,
+		},
+	}
+	redisService, err := redis.NewRedisService(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { redisService.Close() })
+
+	return NewChecker(cfg, WithCache(redisService)), mr
+}
+
+func TestChecker_Cache(t *testing.T) {
+	var hits atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/gone" {
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	checker, mr := newCachedTestChecker(t)
+	urls := []string{ts.URL + "/ok", ts.URL + "/gone"}
+
+	first := checker.Check(context.Background(), urls)
+	assert.False(t, first[ts.URL+"/ok"].Cached)
+	assert.Equal(t, int32(2), hits.Load())
+
+	// Failures are kept for a shorter time than successes
+	assert.Equal(t, time.Hour, mr.TTL(cacheKey(ts.URL+"/ok")))
+	assert.Equal(t, time.Minute, mr.TTL(cacheKey(ts.URL+"/gone")))
+
+	t.Run("Cache hit", func(t *testing.T) {
+		results := checker.Check(context.Background(), urls)
+		assert.Equal(t, int32(2), hits.Load(), "cached links are not checked again")
+
+		ok := results[ts.URL+"/ok"]
+		assert.True(t, ok.Cached)
+		assert.True(t, ok.Reachable())
+		assert.Equal(t, http.StatusOK, ok.StatusCode)
+		assert.Equal(t, ok.Duration.Milliseconds(), first[ts.URL+"/ok"].Duration.Milliseconds())
+
+		gone := results[ts.URL+"/gone"]
+		assert.True(t, gone.Cached)
+		assert.False(t, gone.Reachable())
+		assert.Equal(t, "HTTP 404", gone.Problem())
+		assert.Equal(t, "HTTP_NOT_FOUND", gone.ErrorType())
+		assert.True(t, domainerrors.IsHTTPError(gone.Err))
+	})
+
+	t.Run("Recheck", func(t *testing.T) {
+		results := checker.Check(WithRecheck(context.Background()), urls)
+		assert.Equal(t, int32(4), hits.Load())
+		assert.False(t, results[ts.URL+"/ok"].Cached)
+	})
+
+	t.Run("Redis unavailable", func(t *testing.T) {
+		mr.Close()
+		results := checker.Check(context.Background(), urls)
+		assert.Equal(t, int32(6), hits.Load())
+		assert.True(t, results[ts.URL+"/ok"].Reachable())
+	})
+}
@@ -0,0 +1,371 @@
+package linkcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/services/netguard"
+	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
+)
+
+const (
+	defaultWorkers            = 16
+	defaultPerHostConcurrency = 2
+	defaultTimeout            = 5 * time.Second
+	defaultDeadline           = 8 * time.Second
+	defaultMaxRedirects       = 5
+
+	// maxDeadlineMargin is how long before the caller's own deadline the checks stop, so the
+	// caller still has time to cache the results and report the links left unchecked
+	maxDeadlineMargin = time.Second
+
+	// drainBytes is how much of a GET body is read so the connection can be reused
+	drainBytes = 4 * 1024
+)
+
+// headRejectedStatuses are HEAD responses of servers that only answer GET properly
+var headRejectedStatuses = map[int]bool{
+	http.StatusBadRequest:       true,
+	http.StatusForbidden:        true,
+	http.StatusMethodNotAllowed: true,
+	http.StatusNotImplemented:   true,
+}
+
+// Result is the outcome of checking one link
+type Result struct {
+	URL string
+	// FinalURL is where redirects led; equal to URL when there were none
+	FinalURL   string
+	StatusCode int
+	// Err explains why the link is unreachable as a domain error; nil when it is reachable
+	Err error
+	// Unchecked is set when the deadline passed before the check could finish
+	Unchecked bool
+	// Disallowed is set when robots.txt forbids fetching the link; it is then not checked
+	Disallowed bool
+	// Cached is set when the result was taken from the shared cache; Duration is then that of the original check
+	Cached   bool
+	Duration time.Duration
+}
+
+// Reachable reports whether the link was checked and answered with a non-error status
+func (r Result) Reachable() bool {
+	return !r.Unchecked && !r.Disallowed && r.Err == nil
+}
+
+// Problem describes why a checked link is unreachable, e.g. "HTTP 404"
+func (r Result) Problem() string {
+	if r.Err == nil {
+		return ""
+	}
+	if r.StatusCode >= http.StatusBadRequest {
+		return fmt.Sprintf("HTTP %d", r.StatusCode)
+	}
+	var domainErr *domainerrors.DomainError
+	if errors.As(r.Err, &domainErr) && domainErr.Cause != nil {
+		return domainErr.Cause.Error()
+	}
+	return r.Err.Error()
+}
+
+// ErrorType returns the domain error type of an unreachable link, e.g. HTTP_NOT_FOUND
+func (r Result) ErrorType() string {
+	var domainErr *domainerrors.DomainError
+	if errors.As(r.Err, &domainErr) {
+		return string(domainErr.Type)
+	}
+	return ""
+}
+
+// Option configures a checker
+type Option func(*Checker)
+
+// WithRobots skips links disallowed by robots.txt; a nil service checks every link
+func WithRobots(robotsService *robots.RobotsService) Option {
+	return func(c *Checker) {
+		c.robots = robotsService
+	}
+}
+
+// WithCache shares results across analyses through Redis, with the TTLs of the link check configuration
+// A nil service disables caching
+func WithCache(redisService *redis.RedisService) Option {
+	return func(c *Checker) {
+		if redisService == nil {
+			c.cache = nil
+			return
+		}
+		c.cache = &resultCache{redis: redisService.GetClient()}
+	}
+}
+
+// Checker checks link reachability with a shared client and a bounded worker pool
+type Checker struct {
+	client    *http.Client
+	userAgent string
+	robots    *robots.RobotsService
+	cache     *resultCache
+
+	workers  int
+	perHost  int
+	timeout  time.Duration
+	deadline time.Duration
+}
+
+// NewChecker creates a link checker from the link check configuration
+func NewChecker(cfg *config.Config, options ...Option) *Checker {
+	workers := cfg.LinkCheck.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	perHost := cfg.LinkCheck.PerHostConcurrency
+	if perHost <= 0 {
+		perHost = defaultPerHostConcurrency
+	}
+	timeout := cfg.LinkCheck.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	deadline := cfg.LinkCheck.Deadline
+	if deadline <= 0 {
+		deadline = defaultDeadline
+	}
+	maxRedirects := cfg.Analysis.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	guard := netguard.NewGuard(cfg)
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !cfg.Analysis.VerifySSL,
+			},
+			Proxy:               guard.Proxy,
+			DialContext:         guard.DialContext,
+			ForceAttemptHTTP2:   true,
+			MaxConnsPerHost:     perHost,
+			MaxIdleConnsPerHost: perHost,
+			IdleConnTimeout:     30 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > maxRedirects {
+				return domainerrors.NewTooManyRedirectsError(via[0].URL.String(), maxRedirects, nil, false)
+			}
+			return nil
+		},
+	}
+
+	checker := &Checker{
+		client:    client,
+		userAgent: cfg.App.Name,
+		workers:   workers,
+		perHost:   perHost,
+		timeout:   timeout,
+		deadline:  deadline,
+	}
+	for _, option := range options {
+		option(checker)
+	}
+
+	if checker.cache != nil {
+		checker.cache.ttl = cfg.LinkCheck.CacheTTL
+		if checker.cache.ttl <= 0 {
+			checker.cache.ttl = defaultCacheTTL
+		}
+		checker.cache.failureTTL = cfg.LinkCheck.CacheFailureTTL
+		if checker.cache.failureTTL <= 0 {
+			checker.cache.failureTTL = defaultCacheFailureTTL
+		}
+	}
+	return checker
+}
+
+// Check checks every distinct URL and returns the results keyed by URL
+// Checks stop at the configured deadline, or shortly before the context's deadline if that comes first;
+// links not checked by then are marked unchecked
+// Cached results are used unless the context comes from WithRecheck
+func (c *Checker) Check(ctx context.Context, urls []string) map[string]Result {
+	queue := interleaveByHost(urls)
+	results := make(map[string]Result, len(queue))
+	if c.cache != nil && !recheckRequested(ctx) {
+		for link, result := range c.cache.load(ctx, queue) {
+			results[link] = result
+		}
+		queue = slices.DeleteFunc(queue, func(link string) bool {
+			_, cached := results[link]
+			return cached
+		})
+	}
+	if len(queue) == 0 {
+		return results
+	}
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, c.budget(ctx))
+	defer cancel()
+
+	hosts := make(map[string]chan struct{})
+	for _, link := range queue {
+		if _, ok := hosts[hostOf(link)]; !ok {
+			hosts[hostOf(link)] = make(chan struct{}, c.perHost)
+		}
+	}
+
+	jobs := make(chan string)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < min(c.workers, len(queue)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range jobs {
+				result := c.checkOne(deadlineCtx, link, hosts[hostOf(link)])
+				mu.Lock()
+				results[link] = result
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, link := range queue {
+		jobs <- link
+	}
+	close(jobs)
+	wg.Wait()
+
+	if c.cache != nil {
+		fresh := make([]Result, 0, len(queue))
+		for _, link := range queue {
+			fresh = append(fresh, results[link])
+		}
+		c.cache.store(ctx, fresh)
+	}
+	return results
+}
+
+// budget returns how long the checks may run: the configured deadline, cut short to end a
+// margin before the context's deadline, e.g. the budget of the extractor running the checks
+func (c *Checker) budget(ctx context.Context) time.Duration {
+	ctxDeadline, ok := ctx.Deadline()
+	if !ok {
+		return c.deadline
+	}
+	remaining := time.Until(ctxDeadline)
+	return min(c.deadline, remaining-min(maxDeadlineMargin, remaining/5))
+}
+
+// checkOne checks a single link once its host has a free slot
+func (c *Checker) checkOne(ctx context.Context, link string, hostSlots chan struct{}) Result {
+	result := Result{URL: link}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		result.Err = domainerrors.NewInvalidURLError(link, err)
+		return result
+	}
+
+	select {
+	case hostSlots <- struct{}{}:
+		defer func() { <-hostSlots }()
+	case <-ctx.Done():
+		result.Unchecked = true
+		return result
+	}
+
+	if c.robots != nil && c.robots.Check(ctx, u) != nil {
+		result.Disallowed = true
+		return result
+	}
+
+	start := time.Now()
+	checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	status, finalURL, err := c.request(checkCtx, http.MethodHead, link)
+	if err == nil && headRejectedStatuses[status] {
+		status, finalURL, err = c.request(checkCtx, http.MethodGet, link)
+	}
+	result.Duration = time.Since(start)
+
+	switch {
+	case err != nil && ctx.Err() != nil:
+		// The page's deadline cut the check short; that says nothing about the link
+		result.Unchecked = true
+	case err != nil:
+		result.Err = domainerrors.ClassifyNetworkError(link, err)
+	case status >= http.StatusBadRequest:
+		result.StatusCode = status
+		result.FinalURL = finalURL
+		result.Err = domainerrors.ClassifyHTTPStatusError(link, status)
+	default:
+		result.StatusCode = status
+		result.FinalURL = finalURL
+	}
+	return result
+}
+
+// request sends one request and returns the final status code and URL
+func (c *Checker) request(ctx context.Context, method, link string) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	io.CopyN(io.Discard, resp.Body, drainBytes)
+
+	return resp.StatusCode, resp.Request.URL.String(), nil
+}
+
+// interleaveByHost dedupes the URLs and orders them round-robin across hosts,
+// so workers waiting on a busy host don't hold up the others
+func interleaveByHost(urls []string) []string {
+	seen := make(map[string]bool, len(urls))
+	var hosts []string
+	byHost := make(map[string][]string)
+	for _, link := range urls {
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+		host := hostOf(link)
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], link)
+	}
+
+	queue := make([]string, 0, len(seen))
+	for round := 0; len(queue) < len(seen); round++ {
+		for _, host := range hosts {
+			if round < len(byHost[host]) {
+				queue = append(queue, byHost[host][round])
+			}
+		}
+	}
+	return queue
+}
+
+// hostOf returns the host a URL's checks are limited by
+func hostOf(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		return u.Host
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package linkcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+)
+
+func newTestChecker(linkCheck config.LinkCheckConfig) *Checker {
+	return NewChecker(&config.Config{
+		App:       config.AppConfig{Name: "Test App"},
+		LinkCheck: linkCheck,
+	})
+}
+
+func TestChecker_Statuses(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string][]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path] = append(requests[r.URL.Path], r.Method)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/ok":
+		case "/get-only":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/moved":
+			http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	checker := newTestChecker(config.LinkCheckConfig{})
+	results := checker.Check(context.Background(), []string{
+		ts.URL + "/ok", ts.URL + "/ok", ts.URL + "/ok",
+		ts.URL + "/get-only",
+		ts.URL + "/moved",
+		ts.URL + "/gone",
+	})
+	require.Len(t, results, 4)
+
+	ok := results[ts.URL+"/ok"]
+	assert.True(t, ok.Reachable())
+	assert.Equal(t, http.StatusOK, ok.StatusCode)
+
+	getOnly := results[ts.URL+"/get-only"]
+	assert.True(t, getOnly.Reachable(), "GET fallback after a rejected HEAD: %+v", getOnly)
+	assert.Equal(t, []string{http.MethodHead, http.MethodGet}, requests["/get-only"])
+
+	moved := results[ts.URL+"/moved"]
+	assert.True(t, moved.Reachable())
+	assert.Equal(t, ts.URL+"/ok", moved.FinalURL)
+
+	gone := results[ts.URL+"/gone"]
+	assert.False(t, gone.Reachable())
+	assert.Equal(t, http.StatusNotFound, gone.StatusCode)
+	assert.Equal(t, "HTTP 404", gone.Problem())
+	assert.True(t, domainerrors.IsHTTPError(gone.Err))
+
+	// Duplicates are checked once, with a HEAD only
+	assert.Equal(t, []string{http.MethodHead, http.MethodHead}, requests["/ok"], "one HEAD for /ok and one from the /moved redirect")
+}
+
+func TestChecker_PerHostConcurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			old := peak.Load()
+			if current <= old || peak.CompareAndSwap(old, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer ts.Close()
+
+	checker := newTestChecker(config.LinkCheckConfig{Workers: 8, PerHostConcurrency: 2})
+	var urls []string
+	for _, path := range strings.Split("a b c d e f g h", " ") {
+		urls = append(urls, ts.URL+"/"+path)
+	}
+
+	results := checker.Check(context.Background(), urls)
+	for _, result := range results {
+		assert.True(t, result.Reachable(), "%+v", result)
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestChecker_Timeouts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(500 * time.Millisecond):
+		case <-r.Context().Done():
+		}
+	}))
+	defer ts.Close()
+
+	t.Run("Per-link timeout", func(t *testing.T) {
+		checker := newTestChecker(config.LinkCheckConfig{Timeout: 30 * time.Millisecond, Deadline: time.Second})
+		result := checker.Check(context.Background(), []string{ts.URL + "/slow"})[ts.URL+"/slow"]
+
+		assert.False(t, result.Unchecked)
+		assert.True(t, domainerrors.IsNetworkError(result.Err), "got %v", result.Err)
+	})
+
+	t.Run("Global deadline", func(t *testing.T) {
+		checker := newTestChecker(config.LinkCheckConfig{Workers: 1, Timeout: time.Second, Deadline: 50 * time.Millisecond})
+
+		start := time.Now()
+		results := checker.Check(context.Background(), []string{ts.URL + "/a", ts.URL + "/b", ts.URL + "/c"})
+		assert.Less(t, time.Since(start), 400*time.Millisecond)
+
+		for _, result := range results {
+			assert.True(t, result.Unchecked, "%+v", result)
+			assert.NoError(t, result.Err)
+		}
+	})
+}
+
+func TestInterleaveByHost(t *testing.T) {
+	queue := interleaveByHost([]string{
+		"https://a.com/1", "https://a.com/2", "https://a.com/3",
+		"https://b.com/1", "https://a.com/1", "https://c.com/1",
+	})
+
+	assert.Equal(t, []string{
+		"https://a.com/1", "https://b.com/1", "https://c.com/1",
+		"https://a.com/2", "https://a.com/3",
+	}, queue)
+}
@@ -0,0 +1,180 @@
+package netguard
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+)
+
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+// blockedRange is an address range outbound requests may not reach
+type blockedRange struct {
+	prefix netip.Prefix
+	reason string
+}
+
+// blockedRanges are the internal and special-purpose ranges refused by default
+var blockedRanges = []blockedRange{
+	{netip.MustParsePrefix("0.0.0.0/8"), "unspecified"},
+	{netip.MustParsePrefix("10.0.0.0/8"), "private"},
+	{netip.MustParsePrefix("100.64.0.0/10"), "carrier-grade NAT"},
+	{netip.MustParsePrefix("127.0.0.0/8"), "loopback"},
+	{netip.MustParsePrefix("169.254.0.0/16"), "link-local"}, // Includes the 169.254.169.254 metadata service
+	{netip.MustParsePrefix("172.16.0.0/12"), "private"},
+	{netip.MustParsePrefix("192.0.0.0/24"), "IETF protocol assignments"}, // Includes the 192.0.0.192 metadata service
+	{netip.MustParsePrefix("192.168.0.0/16"), "private"},
+	{netip.MustParsePrefix("198.18.0.0/15"), "benchmarking"},
+	{netip.MustParsePrefix("224.0.0.0/4"), "multicast"},
+	{netip.MustParsePrefix("240.0.0.0/4"), "reserved"},
+	{netip.MustParsePrefix("::/128"), "unspecified"},
+	{netip.MustParsePrefix("64:ff9b:1::/48"), "local-use NAT64"}, // The embedded IPv4 address depends on the translator's prefix length
+	{netip.MustParsePrefix("::1/128"), "loopback"},
+	{netip.MustParsePrefix("fc00::/7"), "unique local"}, // Includes the fd00:ec2::254 metadata service
+	{netip.MustParsePrefix("fe80::/10"), "link-local"},
+	{netip.MustParsePrefix("ff00::/8"), "multicast"},
+}
+
+// Translation prefixes that embed an IPv4 address, which is checked in place of the IPv6 one
+var (
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour   = netip.MustParsePrefix("2002::/16")
+)
+
+// Guard refuses outbound connections to internal networks
+// The check runs on the resolved address of every dial, so neither redirects
+// nor DNS rebinding can steer a fetch to an internal host
+// Requests sent through a proxy are checked by Proxy instead, since only the proxy is dialed
+type Guard struct {
+	enabled bool
+	allowed []netip.Prefix
+	dialer  *net.Dialer
+	proxy   func(*http.Request) (*url.URL, error)
+}
+
+// NewGuard creates a guard from the security configuration
+// With blocking disabled it dials like the default transport
+func NewGuard(cfg *config.Config) *Guard {
+	guard := &Guard{
+		enabled: cfg.Security.BlockPrivateNetworks,
+		proxy:   http.ProxyFromEnvironment,
+	}
+	for _, network := range cfg.Security.AllowedNetworks {
+		if prefix, err := netip.ParsePrefix(network); err == nil {
+			guard.allowed = append(guard.allowed, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(network); err == nil {
+			guard.allowed = append(guard.allowed, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+
+	guard.dialer = &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   guard.control,
+	}
+	return guard
+}
+
+// DialContext dials like net.Dialer, refusing blocked addresses with a DESTINATION_BLOCKED error
+// A nil guard dials without checks
+func (g *Guard) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if g == nil {
+		return (&net.Dialer{Timeout: dialTimeout, KeepAlive: dialKeepAlive}).DialContext(ctx, network, address)
+	}
+	return g.dialer.DialContext(ctx, network, address)
+}
+
+// Proxy picks the proxy from HTTP_PROXY, HTTPS_PROXY and NO_PROXY like http.ProxyFromEnvironment
+// When a request goes through a proxy, its target host is resolved and checked here; the proxy
+// resolves the host again on its own, so a rebinding DNS server is only fully stopped on direct connections
+// A nil guard picks the proxy without checks
+func (g *Guard) Proxy(req *http.Request) (*url.URL, error) {
+	if g == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+
+	proxyURL, err := g.proxy(req)
+	if err != nil || proxyURL == nil || !g.enabled {
+		return proxyURL, err
+	}
+	if err := g.checkHost(req.Context(), req.URL.Hostname()); err != nil {
+		return nil, err
+	}
+	return proxyURL, nil
+}
+
+// checkHost resolves a host name and checks every address it resolves to
+func (g *Guard) checkHost(ctx context.Context, host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return g.Check(addr)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if err := g.Check(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check returns a DESTINATION_BLOCKED error if the address may not be connected to
+func (g *Guard) Check(addr netip.Addr) error {
+	if g == nil || !g.enabled {
+		return nil
+	}
+
+	addr = addr.Unmap()
+	target, via := embeddedIPv4(addr)
+	for _, prefix := range g.allowed {
+		if prefix.Contains(target) {
+			return nil
+		}
+	}
+	for _, blocked := range blockedRanges {
+		if blocked.prefix.Contains(target) {
+			reason := blocked.reason
+			if via != "" {
+				reason = fmt.Sprintf("%s %s embedded in %s address", reason, target, via)
+			}
+			return domainerrors.NewDestinationBlockedError(addr.String(), reason)
+		}
+	}
+	return nil
+}
+
+// embeddedIPv4 returns the IPv4 address a NAT64 or 6to4 address leads to, and the mechanism's name
+// Other addresses are returned unchanged with an empty name
+func embeddedIPv4(addr netip.Addr) (netip.Addr, string) {
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16])), "NAT64"
+	case sixToFour.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), "6to4"
+	}
+	return addr, ""
+}
+
+// control checks the resolved address right before the connection is made
+func (g *Guard) control(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return domainerrors.NewDestinationBlockedError(address, "unparsable address")
+	}
+	return g.Check(addrPort.Addr())
+}
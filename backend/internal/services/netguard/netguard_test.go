@@ -0,0 +1,128 @@
+package netguard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+)
+
+func newTestGuard(allowed ...string) *Guard {
+	return NewGuard(&config.Config{
+		Security: config.SecurityConfig{
+			BlockPrivateNetworks: true,
+			AllowedNetworks:      allowed,
+		},
+	})
+}
+
+func TestGuard_Check(t *testing.T) {
+	guard := newTestGuard("10.20.0.0/16", "192.168.1.5")
+
+	tests := []struct {
+		addr    string
+		blocked bool
+	}{
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.0.0.1", true},
+		{"172.16.5.4", true},
+		{"192.168.0.1", true},
+		{"100.64.0.1", true},
+		{"169.254.169.254", true},
+		{"fd00:ec2::254", true},
+		{"fe80::1", true},
+		{"0.0.0.0", true},
+		{"::ffff:127.0.0.1", true}, // IPv4-mapped loopback
+		{"10.20.3.4", false},       // Allowlisted network
+		{"192.168.1.5", false},     // Allowlisted address
+		{"192.168.1.6", true},
+		{"64:ff9b::10.0.0.1", true},        // NAT64 to a private address
+		{"64:ff9b::169.254.169.254", true}, // NAT64 to the metadata service
+		{"64:ff9b::93.184.216.34", false},
+		{"64:ff9b::10.20.3.4", false}, // NAT64 to an allowlisted network
+		{"64:ff9b:1::a00:1", true},    // Local-use NAT64
+		{"2002:7f00:1::1", true},      // 6to4 for 127.0.0.1
+		{"2002:c0a8:6::1", true},      // 6to4 for 192.168.0.6
+		{"2002:5db8:d822::1", false},  // 6to4 for 93.184.216.34
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			err := guard.Check(netip.MustParseAddr(tt.addr))
+			if tt.blocked {
+				assert.True(t, domainerrors.IsDestinationBlockedError(err), "want %s blocked, got %v", tt.addr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGuard_Disabled(t *testing.T) {
+	guard := NewGuard(&config.Config{})
+	assert.NoError(t, guard.Check(netip.MustParseAddr("127.0.0.1")))
+
+	var nilGuard *Guard
+	assert.NoError(t, nilGuard.Check(netip.MustParseAddr("127.0.0.1")))
+}
+
+func TestGuard_DialContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	get := func(guard *Guard) error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, ts.URL, nil)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: &http.Transport{DialContext: guard.DialContext}}).Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// The loopback test server is refused at dial time
+	err := get(newTestGuard())
+	assert.True(t, domainerrors.IsDestinationBlockedError(err), "got %v", err)
+
+	assert.NoError(t, get(newTestGuard("127.0.0.0/8")))
+}
+
+func TestGuard_Proxy(t *testing.T) {
+	// The proxy answers every request itself, so a request only fails if the guard refuses it
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+
+	get := func(guard *Guard, target string) error {
+		guard.proxy = http.ProxyURL(proxyURL)
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: &http.Transport{Proxy: guard.Proxy, DialContext: guard.DialContext}}).Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// Only the proxy is dialed, so the target is checked before the request is proxied
+	err = get(newTestGuard("127.0.0.1"), "http://169.254.169.254/latest/meta-data/")
+	assert.True(t, domainerrors.IsDestinationBlockedError(err), "got %v", err)
+	err = get(newTestGuard("127.0.0.1"), "http://[fd00:ec2::254]/")
+	assert.True(t, domainerrors.IsDestinationBlockedError(err), "got %v", err)
+
+	assert.NoError(t, get(newTestGuard("127.0.0.1", "169.254.0.0/16"), "http://169.254.169.254/latest/meta-data/"))
+	assert.NoError(t, get(newTestGuard("127.0.0.1"), "http://93.184.216.34/"))
+	assert.NoError(t, get(NewGuard(&config.Config{}), "http://10.0.0.1/"))
+}
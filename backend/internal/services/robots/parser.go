@@ -0,0 +1,208 @@
+package robots
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCrawlDelay bounds parsed Crawl-delay values so huge ones can't overflow a time.Duration
+const maxCrawlDelay = 24 * time.Hour
+
+// Rules is a parsed robots.txt file (RFC 9309)
+type Rules struct {
+	groups []group
+}
+
+// group is a set of rules shared by one or more user-agent lines
+type group struct {
+	agents     []string
+	rules      []rule
+	crawlDelay time.Duration
+}
+
+// rule is a single Allow or Disallow line
+type rule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// Decision is the outcome of matching a path against the rules
+type Decision struct {
+	Allowed bool
+	// Rule is the matching line (e.g. "Disallow: /private"), empty if no rule matched
+	Rule string
+	// Agent is the user-agent line of the group that applied, empty if no group applied
+	Agent string
+}
+
+// AllowAll returns rules that allow every path
+func AllowAll() *Rules {
+	return &Rules{}
+}
+
+// DisallowAll returns rules that disallow every path
+func DisallowAll() *Rules {
+	return &Rules{groups: []group{{
+		agents: []string{"*"},
+		rules:  []rule{newRule(false, "/")},
+	}}}
+}
+
+// Parse reads a robots.txt body; unknown lines and lines outside a group are ignored
+// Lines have no length limit, so callers bound the size of r
+func Parse(r io.Reader) *Rules {
+	rules := &Rules{}
+	var current *group
+	lastWasAgent := false
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines share one group
+			if current == nil || !lastWasAgent {
+				rules.groups = append(rules.groups, group{})
+				current = &rules.groups[len(rules.groups)-1]
+			}
+			current.agents = append(current.agents, strings.ToLower(value))
+			lastWasAgent = true
+			continue
+		case "allow", "disallow":
+			// An empty value means "no rule"
+			if current != nil && value != "" {
+				current.rules = append(current.rules, newRule(key == "allow", value))
+			}
+		case "crawl-delay":
+			if current != nil {
+				if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+					seconds = min(seconds, maxCrawlDelay.Seconds())
+					current.crawlDelay = time.Duration(seconds * float64(time.Second))
+				}
+			}
+		}
+		lastWasAgent = false
+	}
+
+	return rules
+}
+
+// Match decides whether the given user agent may fetch the path (including any query)
+// The longest matching pattern wins; on a tie Allow wins; no match means allowed
+func (r *Rules) Match(userAgent, path string) Decision {
+	if path == "" {
+		path = "/"
+	}
+	if path == "/robots.txt" {
+		return Decision{Allowed: true}
+	}
+
+	groups, agent := r.groupsFor(userAgent)
+	decision := Decision{Allowed: true, Agent: agent}
+
+	best := -1
+	for _, g := range groups {
+		for _, rl := range g.rules {
+			if !rl.re.MatchString(path) {
+				continue
+			}
+			length := len(rl.pattern)
+			if length > best || (length == best && rl.allow && !decision.Allowed) {
+				best = length
+				decision.Allowed = rl.allow
+				decision.Rule = rl.String()
+			}
+		}
+	}
+
+	return decision
+}
+
+// CrawlDelay returns the crawl delay of the group that applies to the user agent
+func (r *Rules) CrawlDelay(userAgent string) time.Duration {
+	groups, _ := r.groupsFor(userAgent)
+
+	var delay time.Duration
+	for _, g := range groups {
+		if g.crawlDelay > delay {
+			delay = g.crawlDelay
+		}
+	}
+	return delay
+}
+
+// groupsFor selects the groups for a user agent product token
+// The longest user-agent value that prefixes the token wins and all groups
+// naming it are merged; otherwise the "*" groups apply
+func (r *Rules) groupsFor(userAgent string) ([]group, string) {
+	token := strings.ToLower(userAgent)
+
+	bestAgent := ""
+	for _, g := range r.groups {
+		for _, agent := range g.agents {
+			if agent != "*" && agent != "" && strings.HasPrefix(token, agent) && len(agent) > len(bestAgent) {
+				bestAgent = agent
+			}
+		}
+	}
+	if bestAgent == "" {
+		bestAgent = "*"
+	}
+
+	var selected []group
+	for _, g := range r.groups {
+		for _, agent := range g.agents {
+			if agent == bestAgent {
+				selected = append(selected, g)
+				break
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, ""
+	}
+	return selected, bestAgent
+}
+
+// newRule compiles a path pattern where "*" matches any characters and a trailing "$" anchors the end
+func newRule(allow bool, pattern string) rule {
+	anchored := strings.HasSuffix(pattern, "$")
+	expr := strings.TrimSuffix(pattern, "$")
+	expr = "^" + strings.ReplaceAll(regexp.QuoteMeta(expr), `\*`, ".*")
+	if anchored {
+		expr += "$"
+	}
+
+	return rule{
+		allow:   allow,
+		pattern: pattern,
+		re:      regexp.MustCompile(expr),
+	}
+}
+
+// String renders the rule the way it appears in robots.txt
+func (rl rule) String() string {
+	if rl.allow {
+		return "Allow: " + rl.pattern
+	}
+	return "Disallow: " + rl.pattern
+}
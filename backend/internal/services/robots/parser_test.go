@@ -0,0 +1,98 @@
+package robots
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleRobots = `
+# Example robots.txt
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+Crawl-delay: 2
+
+User-agent: PageInsight
+User-agent: OtherBot
+Disallow: /bots-only
+Disallow:
+Crawl-delay: 0.5
+
+User-agent: pageinsighttool
+Disallow: /tool
+`
+
+func TestRules_Match(t *testing.T) {
+	rules := Parse(strings.NewReader(sampleRobots))
+
+	tests := []struct {
+		name      string
+		userAgent string
+		path      string
+		allowed   bool
+		rule      string
+		agent     string
+	}{
+		{"no match is allowed", "SomeBot", "/", true, "", "*"},
+		{"disallowed prefix", "SomeBot", "/private/page", false, "Disallow: /private", "*"},
+		{"longer allow wins", "SomeBot", "/private/public/page", true, "Allow: /private/public", "*"},
+		{"end anchor", "SomeBot", "/files/report.pdf", false, "Disallow: /*.pdf$", "*"},
+		{"end anchor does not match longer path", "SomeBot", "/files/report.pdf?x=1", true, "", "*"},
+		{"robots.txt always allowed", "SomeBot", "/robots.txt", true, "", ""},
+		{"shared group", "OtherBot/2.0", "/bots-only", false, "Disallow: /bots-only", "otherbot"},
+		{"specific group replaces wildcard group", "OtherBot", "/private", true, "", "otherbot"},
+		{"longest agent match wins", "PageInsightTool", "/tool", false, "Disallow: /tool", "pageinsighttool"},
+		{"less specific group ignored", "PageInsightTool", "/bots-only", true, "", "pageinsighttool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := rules.Match(tt.userAgent, tt.path)
+			assert.Equal(t, tt.allowed, decision.Allowed)
+			assert.Equal(t, tt.rule, decision.Rule)
+			if tt.agent != "" {
+				assert.Equal(t, tt.agent, decision.Agent)
+			}
+		})
+	}
+}
+
+func TestRules_TieGoesToAllow(t *testing.T) {
+	rules := Parse(strings.NewReader("User-agent: *\nDisallow: /page\nAllow: /page\n"))
+
+	decision := rules.Match("AnyBot", "/page")
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, "Allow: /page", decision.Rule)
+}
+
+func TestRules_CrawlDelay(t *testing.T) {
+	rules := Parse(strings.NewReader(sampleRobots))
+
+	assert.Equal(t, 2*time.Second, rules.CrawlDelay("SomeBot"))
+	assert.Equal(t, 500*time.Millisecond, rules.CrawlDelay("OtherBot"))
+	assert.Equal(t, time.Duration(0), rules.CrawlDelay("PageInsightTool"))
+
+	// Huge delays are capped instead of overflowing
+	for _, value := range []string{"1e300", "+Inf", "9223372037"} {
+		rules = Parse(strings.NewReader("User-agent: *\nCrawl-delay: " + value + "\n"))
+		assert.Equal(t, maxCrawlDelay, rules.CrawlDelay("SomeBot"), value)
+	}
+}
+
+func TestParse_LongLines(t *testing.T) {
+	long := "Disallow: /" + strings.Repeat("a", 100*1024)
+	rules := Parse(strings.NewReader("User-agent: *\r\n" + long + "\r\nDisallow: /private"))
+
+	assert.False(t, rules.Match("SomeBot", "/"+strings.Repeat("a", 100*1024)).Allowed)
+	assert.False(t, rules.Match("SomeBot", "/private").Allowed, "lines after a long line are parsed")
+}
+
+func TestAllowAllAndDisallowAll(t *testing.T) {
+	assert.True(t, AllowAll().Match("AnyBot", "/anything").Allowed)
+	assert.False(t, DisallowAll().Match("AnyBot", "/anything").Allowed)
+	assert.True(t, DisallowAll().Match("AnyBot", "/robots.txt").Allowed)
+}
@@ -0,0 +1,239 @@
+package robots
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+	"github.com/steve-phan/page-insight-tool/internal/models"
+	"github.com/steve-phan/page-insight-tool/internal/services/netguard"
+)
+
+const (
+	defaultUserAgent     = "PageInsightTool"
+	defaultCacheTTL      = 24 * time.Hour
+	defaultMaxCrawlDelay = 10 * time.Second
+	defaultFetchTimeout  = 10 * time.Second
+
+	// failureTTL keeps unreachable or failed robots.txt lookups from being retried on every request
+	failureTTL = 5 * time.Minute
+	// maxRobotsSize is the parse limit required by RFC 9309
+	maxRobotsSize = 500 * 1024
+	// maxCacheEntries triggers a sweep of expired hosts
+	maxCacheEntries = 10000
+)
+
+// robots.txt fetch outcomes
+const (
+	StatusFetched     = "fetched"     // 2xx: rules parsed
+	StatusMissing     = "missing"     // 4xx: everything allowed
+	StatusUnreachable = "unreachable" // 5xx: everything disallowed
+	StatusError       = "error"       // network failure: allowed, the page fetch reports the real error
+)
+
+// RobotsService fetches, parses and caches robots.txt rules per host
+type RobotsService struct {
+	httpClient    *http.Client
+	userAgent     string
+	cacheTTL      time.Duration
+	maxCrawlDelay time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*entry
+}
+
+// entry is a cached robots.txt lookup; ready is closed once rules and status are set
+type entry struct {
+	ready   chan struct{}
+	rules   *Rules
+	status  string
+	expires time.Time
+}
+
+// NewRobotsService creates a new robots.txt service
+func NewRobotsService(cfg *config.Config) *RobotsService {
+	userAgent := cfg.Robots.UserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	cacheTTL := cfg.Robots.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+	maxCrawlDelay := cfg.Robots.MaxCrawlDelay
+	if maxCrawlDelay <= 0 {
+		maxCrawlDelay = defaultMaxCrawlDelay
+	}
+	timeout := cfg.Analysis.Timeout * time.Second
+	if timeout <= 0 {
+		timeout = defaultFetchTimeout
+	}
+
+	guard := netguard.NewGuard(cfg)
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !cfg.Analysis.VerifySSL,
+			},
+			Proxy:               guard.Proxy,
+			DialContext:         guard.DialContext,
+			IdleConnTimeout:     30 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+
+	return &RobotsService{
+		httpClient:    client,
+		userAgent:     userAgent,
+		cacheTTL:      cacheTTL,
+		maxCrawlDelay: maxCrawlDelay,
+		cache:         make(map[string]*entry),
+	}
+}
+
+// UserAgent returns the product token matched against robots.txt groups
+func (s *RobotsService) UserAgent() string {
+	return s.userAgent
+}
+
+// Check returns a ROBOTS_DISALLOWED domain error when the URL may not be fetched
+func (s *RobotsService) Check(ctx context.Context, u *url.URL) error {
+	rules, _ := s.rulesFor(ctx, u)
+
+	decision := rules.Match(s.userAgent, u.RequestURI())
+	if !decision.Allowed {
+		return domainerrors.NewRobotsDisallowedError(u.String(), s.userAgent, decision.Rule)
+	}
+	return nil
+}
+
+// CrawlDelay returns the Crawl-delay that applies to the URL's host, capped by configuration
+func (s *RobotsService) CrawlDelay(ctx context.Context, u *url.URL) time.Duration {
+	rules, _ := s.rulesFor(ctx, u)
+
+	delay := rules.CrawlDelay(s.userAgent)
+	if delay > s.maxCrawlDelay {
+		return s.maxCrawlDelay
+	}
+	return delay
+}
+
+// Test reports the robots.txt decision for a URL without fetching the URL itself
+func (s *RobotsService) Test(ctx context.Context, rawURL string) (models.RobotsCheckResponse, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.RobotsCheckResponse{}, domainerrors.NewInvalidURLError(rawURL, err)
+	}
+
+	rules, status := s.rulesFor(ctx, u)
+	decision := rules.Match(s.userAgent, u.RequestURI())
+
+	return models.RobotsCheckResponse{
+		URL:        u.String(),
+		RobotsURL:  robotsURL(u),
+		UserAgent:  s.userAgent,
+		Allowed:    decision.Allowed,
+		Group:      decision.Agent,
+		Rule:       decision.Rule,
+		CrawlDelay: rules.CrawlDelay(s.userAgent).Seconds(),
+		Status:     status,
+	}, nil
+}
+
+// rulesFor returns the cached rules for the URL's origin, fetching them once per TTL
+// Concurrent callers for the same origin share a single fetch, and each one waits for it
+// only as long as its own context allows; a caller that gives up is allowed, like a network error
+func (s *RobotsService) rulesFor(ctx context.Context, u *url.URL) (*Rules, string) {
+	key := robotsURL(u)
+
+	s.mu.Lock()
+	e, ok := s.cache[key]
+	if !ok || s.expired(e) {
+		if len(s.cache) >= maxCacheEntries {
+			s.sweep()
+		}
+		e = &entry{ready: make(chan struct{})}
+		s.cache[key] = e
+		go s.load(key, e)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-e.ready:
+		return e.rules, e.status
+	case <-ctx.Done():
+		return AllowAll(), StatusError
+	}
+}
+
+// load fetches the rules of a cache entry and marks it ready
+// The fetch is detached from the callers so a cancelled request doesn't poison the cache
+func (s *RobotsService) load(robotsURL string, e *entry) {
+	e.rules, e.status = s.fetch(context.Background(), robotsURL)
+	ttl := s.cacheTTL
+	if e.status == StatusUnreachable || e.status == StatusError {
+		ttl = failureTTL
+	}
+	e.expires = time.Now().Add(ttl)
+	close(e.ready)
+}
+
+// fetch downloads and parses robots.txt following RFC 9309 status handling
+func (s *RobotsService) fetch(ctx context.Context, robotsURL string) (*Rules, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return AllowAll(), StatusError
+	}
+	// Send the product token groups are matched against, so operators can target the agent they see in their logs
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return AllowAll(), StatusError
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return Parse(io.LimitReader(resp.Body, maxRobotsSize)), StatusFetched
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return AllowAll(), StatusMissing
+	case resp.StatusCode >= 500:
+		return DisallowAll(), StatusUnreachable
+	default:
+		return AllowAll(), StatusError
+	}
+}
+
+// expired reports whether a finished cache entry has outlived its TTL; must hold s.mu
+func (s *RobotsService) expired(e *entry) bool {
+	select {
+	case <-e.ready:
+		return time.Now().After(e.expires)
+	default:
+		return false // Fetch still in flight
+	}
+}
+
+// sweep drops expired cache entries; must hold s.mu
+func (s *RobotsService) sweep() {
+	for key, e := range s.cache {
+		if s.expired(e) {
+			delete(s.cache, key)
+		}
+	}
+}
+
+// robotsURL returns the robots.txt location for the URL's origin
+func robotsURL(u *url.URL) string {
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + "/robots.txt"
+}
@@ -0,0 +1,139 @@
+package robots
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steve-phan/page-insight-tool/internal/config"
+	domainerrors "github.com/steve-phan/page-insight-tool/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRobotsService() *RobotsService {
+	return NewRobotsService(&config.Config{
+		Analysis: config.AnalysisConfig{Timeout: 5},
+		App:      config.AppConfig{Name: "Test App"},
+		Robots: config.RobotsConfig{
+			Enabled:       true,
+			UserAgent:     "PageInsightTool",
+			MaxCrawlDelay: 3 * time.Second,
+		},
+	})
+}
+
+func newRobotsServer(t *testing.T, status int, body string) (*httptest.Server, *int32) {
+	var fetches int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&fetches, 1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &fetches
+}
+
+func mustParse(t *testing.T, rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u
+}
+
+func TestRobotsService_Check(t *testing.T) {
+	ts, fetches := newRobotsServer(t, http.StatusOK, "User-agent: *\nDisallow: /private\nCrawl-delay: 60\n")
+	service := newTestRobotsService()
+	ctx := context.Background()
+
+	assert.NoError(t, service.Check(ctx, mustParse(t, ts.URL+"/public")))
+
+	err := service.Check(ctx, mustParse(t, ts.URL+"/private/page"))
+	require.Error(t, err)
+	assert.True(t, domainerrors.IsRobotsDisallowedError(err))
+
+	var domainErr *domainerrors.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, http.StatusForbidden, domainErr.StatusCode)
+	assert.Equal(t, "Disallow: /private", domainErr.Details["rule"])
+
+	assert.Equal(t, 3*time.Second, service.CrawlDelay(ctx, mustParse(t, ts.URL)), "crawl delay is capped by configuration")
+	assert.Equal(t, int32(1), atomic.LoadInt32(fetches), "robots.txt is fetched once and cached")
+}
+
+func TestRobotsService_SendsProductToken(t *testing.T) {
+	agents := make(chan string, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		agents <- r.Header.Get("User-Agent")
+		_, _ = w.Write([]byte("User-agent: *\nDisallow:\n"))
+	}))
+	t.Cleanup(ts.Close)
+
+	require.NoError(t, newTestRobotsService().Check(context.Background(), mustParse(t, ts.URL+"/page")))
+	assert.Equal(t, "PageInsightTool", <-agents)
+}
+
+func TestRobotsService_Test(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		allowed bool
+		state   string
+	}{
+		{"fetched", http.StatusOK, "User-agent: pageinsighttool\nDisallow: /page\n", false, StatusFetched},
+		{"missing allows everything", http.StatusNotFound, "", true, StatusMissing},
+		{"unreachable disallows everything", http.StatusServiceUnavailable, "", false, StatusUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, _ := newRobotsServer(t, tt.status, tt.body)
+			service := newTestRobotsService()
+
+			response, err := service.Test(context.Background(), ts.URL+"/page")
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, response.Allowed)
+			assert.Equal(t, tt.state, response.Status)
+			assert.Equal(t, ts.URL+"/robots.txt", response.RobotsURL)
+			assert.Equal(t, "PageInsightTool", response.UserAgent)
+		})
+	}
+}
+
+func TestRobotsService_TestInvalidURL(t *testing.T) {
+	service := newTestRobotsService()
+
+	_, err := service.Test(context.Background(), "ftp://example.com/file")
+	assert.Error(t, err)
+}
+
+func TestRobotsService_SlowFetchHonorsCallerDeadline(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+	}))
+	t.Cleanup(ts.Close)
+	t.Cleanup(func() { close(release) })
+	service := newTestRobotsService()
+
+	// The first caller doesn't wait past its own deadline for robots.txt
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.NoError(t, service.Check(ctx, mustParse(t, ts.URL+"/private")))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The fetch keeps running and serves later callers once it finishes
+	release <- struct{}{}
+	assert.Error(t, service.Check(context.Background(), mustParse(t, ts.URL+"/private")))
+}
@@ -3,14 +3,22 @@ package services
 import (
 	"github.com/steve-phan/page-insight-tool/internal/config"
 	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
+	"github.com/steve-phan/page-insight-tool/internal/services/batch"
+	"github.com/steve-phan/page-insight-tool/internal/services/crawler"
 	"github.com/steve-phan/page-insight-tool/internal/services/health"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
 	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 )
 
 // Services holds all application services
 type Services struct {
 	Config   *config.Config
 	Analyzer *analyzer.AnalyzerService
+	Batch    *batch.BatchService
+	Crawler  *crawler.CrawlerService
 	Health   *health.HealthService
+	Jobs     *jobs.JobService
 	Redis    *redis.RedisService
+	Robots   *robots.RobotsService
 }
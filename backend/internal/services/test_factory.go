@@ -4,8 +4,12 @@ import (
 	"github.com/steve-phan/page-insight-tool/internal/config"
 	analyzer "github.com/steve-phan/page-insight-tool/internal/services/analyzer"
 	"github.com/steve-phan/page-insight-tool/internal/services/analyzer/extractors"
+	"github.com/steve-phan/page-insight-tool/internal/services/batch"
+	"github.com/steve-phan/page-insight-tool/internal/services/crawler"
 	"github.com/steve-phan/page-insight-tool/internal/services/health"
+	"github.com/steve-phan/page-insight-tool/internal/services/jobs"
 	"github.com/steve-phan/page-insight-tool/internal/services/redis"
+	"github.com/steve-phan/page-insight-tool/internal/services/robots"
 )
 
 // TestServiceFactory creates services suitable for testing infrastructure components
@@ -27,6 +31,10 @@ func (tsf *TestServiceFactory) CreateServices() (*Services, error) {
 	// Create test Redis service (no actual connection for tests)
 	redisService := redis.NewTestRedisService(tsf.config)
 
+	// Robots service backs the robots endpoint; enforcement stays off for tests
+	robotsService := robots.NewRobotsService(tsf.config)
+	var enforcedRobots *robots.RobotsService
+
 	// For infrastructure tests, we just need a working analyzer with minimal extractors
 	// Use only TitleExtractor as it's the simplest and most reliable
 	analyzerService, err := analyzer.NewAnalyzerService(tsf.config,
@@ -38,13 +46,28 @@ func (tsf *TestServiceFactory) CreateServices() (*Services, error) {
 		return nil, err
 	}
 
+	// Create batch service on top of the analyzer
+	batchService := batch.NewBatchService(tsf.config, analyzerService)
+
+	// Create crawler service following internal links
+	crawlerService := crawler.NewCrawlerService(tsf.config, analyzerService, enforcedRobots)
+
+	// Create job service using Redis as queue and result store
+	jobService := jobs.NewJobService(tsf.config, redisService, analyzerService,
+		jobs.WithBatch(batchService),
+		jobs.WithCrawler(crawlerService))
+
 	// Create health service
 	healthService := health.NewHealthService(tsf.config)
 
 	return &Services{
 		Config:   tsf.config,
 		Analyzer: analyzerService,
+		Batch:    batchService,
+		Crawler:  crawlerService,
 		Health:   healthService,
+		Jobs:     jobService,
 		Redis:    redisService,
+		Robots:   robotsService,
 	}, nil
 }